@@ -4,54 +4,35 @@ Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/spf13/cobra"
 	"github.com/spolivin/jobtracker/v2/internal/db"
-	"github.com/spolivin/jobtracker/v2/internal/db/config"
 )
 
 var company string
 var position string
 var status string
+var addForce bool
+var addTags []string
 
 var addCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a new job application",
 	RunE: func(cmd *cobra.Command, args []string) error {
-
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
-		}
-
-		password, err := config.GetPassword()
-		if err != nil {
-			return err
-		}
-
 		ctx := cmd.Context()
 
-		dbase, err := db.Connect(ctx, cfg, password)
+		workflow, err := db.NewWorkflow(cfg.Workflow)
 		if err != nil {
 			return err
 		}
-		defer dbase.Close()
-		// Check if 'applications' table exists in Postgres
-		tableExists, err := db.CheckTableExists(ctx, dbase, "applications")
-		if err != nil {
-			return err
-		}
-		if !tableExists {
-			return fmt.Errorf("Add cannot proceed: table 'applications' does not exist. Run `jobtracker migrate` to create one.")
-		}
 
 		// Add the job application to the database
-		store := db.NewJobApplicationStore(dbase)
-		if err := store.Add(ctx, company, position, status); err != nil {
+		store := db.NewStore(dbase)
+		store.SetWorkflow(workflow)
+		id, err := store.Add(ctx, company, position, status, addTags, addForce)
+		if err != nil {
 			return err
 		}
-		cmd.Println("Job application added successfully")
+		cmd.Printf("Job application added successfully (ID: %d)\n", id)
 		return nil
 	},
 }
@@ -59,10 +40,13 @@ var addCmd = &cobra.Command{
 func init() {
 
 	rootCmd.AddCommand(addCmd)
+	requireDatabase(addCmd)
 
 	addCmd.Flags().StringVarP(&company, "company", "c", "", "Company name")
 	addCmd.Flags().StringVarP(&position, "position", "p", "", "Job position")
 	addCmd.Flags().StringVarP(&status, "status", "s", "Applied", "Job status")
+	addCmd.Flags().StringSliceVarP(&addTags, "tag", "t", nil, "Tag to attach (repeatable or comma-separated)")
+	addCmd.Flags().BoolVarP(&addForce, "force", "f", false, "Allow a status outside of the configured workflow")
 
 	addCmd.MarkFlagRequired("company")
 	addCmd.MarkFlagRequired("position")
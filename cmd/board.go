@@ -0,0 +1,92 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+	"github.com/spolivin/jobtracker/v2/internal/tui"
+	"golang.org/x/term"
+)
+
+// defaultBoardWidth is the width of the board when the output is not a terminal
+const defaultBoardWidth = 80
+
+var boardFilter filterFlags
+var boardInteractive bool
+
+// boardCmd represents the board command
+var boardCmd = &cobra.Command{
+	Use:   "board",
+	Short: "Show job applications as a kanban board with a column per status",
+	Long: `Show job applications as a kanban board with a column per status, in the order of the workflow.
+Cards show the company, position, ID and age of the application and are truncated to the terminal width.
+
+With --interactive, cards are moved between columns, changing the status of their applications:
+  ←/→, h/l                   select a column
+  ↑/↓, k/j                   select a card
+  shift+←/→, </>, H/L        move the selected card to the previous or next column
+  r                          reload
+  q                          quit`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		filter, err := boardFilter.filter()
+		if err != nil {
+			return err
+		}
+		workflow, err := db.NewWorkflow(cfg.Workflow)
+		if err != nil {
+			return err
+		}
+		// --status picks the columns, in the order given
+		statuses := workflow.Statuses()
+		if len(filter.Statuses) > 0 {
+			statuses = make([]string, len(filter.Statuses))
+			for i, status := range filter.Statuses {
+				if statuses[i], err = workflow.NormalizeStatus(status); err != nil {
+					statuses[i] = status
+				}
+			}
+		}
+		store := db.NewStore(dbase)
+		store.SetWorkflow(workflow)
+
+		if boardInteractive {
+			if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+				return fmt.Errorf("--interactive needs an interactive terminal")
+			}
+			return tui.RunBoard(ctx, store, filter, statuses)
+		}
+
+		rows, err := store.Read(ctx, "updated_at", true, filter)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 && !renderEmpty() {
+			if !filter.IsZero() {
+				fmt.Fprintln(os.Stderr, "No job applications match the specified filters.")
+			} else {
+				fmt.Fprintln(os.Stderr, "Table is empty: no job applications found in the database.")
+			}
+			return nil
+		}
+		width, _, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil || width <= 0 {
+			width = defaultBoardWidth
+		}
+		return render(display.BoardDataset(display.GroupByStatus(rows, statuses), width), false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(boardCmd)
+	requireDatabase(boardCmd)
+	boardFilter.register(boardCmd)
+	boardCmd.Flags().BoolVarP(&boardInteractive, "interactive", "i", false, "Move cards between columns interactively")
+}
@@ -11,45 +11,43 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spolivin/jobtracker/v2/internal/db"
-	"github.com/spolivin/jobtracker/v2/internal/db/config"
 )
 
 var force bool
+var clearFilter filterFlags
 
 // clearCmd represents the clear command
 var clearCmd = &cobra.Command{
 	Use:   "clear",
-	Short: "Clear all job applications",
+	Short: "Clear all job applications, or only the ones matching filters",
 	RunE: func(cmd *cobra.Command, args []string) error {
-
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
-		}
-
-		password, err := config.GetPassword()
-		if err != nil {
-			return err
-		}
 		ctx := cmd.Context()
 
-		dbase, err := db.Connect(ctx, cfg, password)
+		filter, err := clearFilter.filter()
 		if err != nil {
 			return err
 		}
-		defer dbase.Close()
-		// Check if 'applications' table exists in Postgres
-		tableExists, err := db.CheckTableExists(ctx, dbase, "applications")
+		store := db.NewStore(dbase)
+		matching, err := store.Count(ctx, filter)
 		if err != nil {
 			return err
 		}
-		if !tableExists {
-			return fmt.Errorf("Clear cannot proceed: table 'applications' does not exist.")
+		if matching == 0 && !filter.IsZero() {
+			fmt.Fprintln(os.Stderr, "No job applications match the specified filters.")
+			return nil
+		}
+		if matching == 0 {
+			fmt.Fprintln(os.Stderr, "Table is empty: no job applications found in the database.")
+			return nil
 		}
 		// Prompt user for confirmation
 		if !force {
 			reader := bufio.NewReader(os.Stdin)
-			fmt.Print("Are you sure you want to delete all job applications? (y/N): ")
+			if filter.IsZero() {
+				fmt.Print("Are you sure you want to delete all job applications? (y/N): ")
+			} else {
+				fmt.Printf("Are you sure you want to delete %d matching job application(s)? (y/N): ", matching)
+			}
 			answer, _ := reader.ReadString('\n')
 			answer = strings.TrimSpace(strings.ToLower(answer))
 			if answer != "y" && answer != "yes" {
@@ -57,16 +55,20 @@ var clearCmd = &cobra.Command{
 				return nil
 			}
 		}
-		// Clearing all job applications
-		store := db.NewJobApplicationStore(dbase)
-		rows, err := store.Read(ctx, sortBy, descending)
-		if err != nil {
-			return err
-		}
-		if len(rows) == 0 {
-			fmt.Fprintln(os.Stderr, "Table is empty: no job applications found in the database.")
+		// Deleting only the matching job applications keeps the ID sequence intact
+		if !filter.IsZero() {
+			deleted, err := store.DeleteMatching(ctx, filter)
+			if err != nil {
+				return err
+			}
+			// Applications may have changed while waiting for the confirmation, so the deleted ones are reported
+			if deleted != int64(matching) {
+				fmt.Fprintf(os.Stderr, "Matching job applications changed since the confirmation (%d expected).\n", matching)
+			}
+			cmd.Printf("%d job application(s) have been cleared successfully.\n", deleted)
 			return nil
 		}
+		// Clearing all job applications
 		if err = store.Clear(ctx); err != nil {
 			return err
 		}
@@ -77,6 +79,8 @@ var clearCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(clearCmd)
+	requireDatabase(clearCmd)
 
 	clearCmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation")
+	clearFilter.register(clearCmd)
 }
@@ -5,10 +5,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+	"golang.org/x/term"
 )
 
 // configCmd represents the config command
@@ -16,21 +20,204 @@ var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Show current connection config",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.LoadConfig()
+		cfg, err := loadConfig()
 		if err != nil {
-			return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
+			return err
 		}
-		configInfo := fmt.Sprintf("host=%s\nport=%s\nuser=%s\ndbname=%s",
+		if cfg.DriverName() == config.DriverSQLite {
+			cmd.Printf("profile=%s\ndriver=%s\npath=%s\n", cfg.Profile, cfg.DriverName(), cfg.Target())
+			return nil
+		}
+		configInfo := fmt.Sprintf("profile=%s\nhost=%s\nport=%s\nuser=%s\ndbname=%s",
+			cfg.Profile,
 			cfg.DBHost,
 			strconv.Itoa(cfg.DBPort),
 			cfg.DBUser,
 			cfg.DBName,
 		)
+		if dsn := cfg.DatabaseURL(); dsn != "" {
+			configInfo = fmt.Sprintf("profile=%s\nurl=%s", cfg.Profile, config.RedactDSN(dsn))
+		}
+		// Optional settings are only shown when configured
+		var keyring string
+		if cfg.Keyring {
+			keyring = "true"
+		}
+		for _, setting := range [][2]string{
+			{"sslmode", cfg.SSLMode},
+			{"sslrootcert", cfg.SSLRootCert},
+			{"sslcert", cfg.SSLCert},
+			{"sslkey", cfg.SSLKey},
+			{"application_name", cfg.ApplicationName},
+			{"connect_timeout", cfg.ConnectTimeout},
+			{"statement_timeout", cfg.StatementTimeout},
+			{"password_command", cfg.PasswordCommand},
+			{"keyring", keyring},
+		} {
+			if setting[1] != "" {
+				configInfo += "\n" + setting[0] + "=" + setting[1]
+			}
+		}
+		source, err := cfg.PasswordSource()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Warning:", err)
+		}
+		sources := make([]string, len(config.PasswordSources))
+		for i, s := range config.PasswordSources {
+			sources[i] = string(s)
+		}
+		configInfo += fmt.Sprintf("\npassword_source=%s\npassword_precedence=%s", source, strings.Join(sources, " > "))
 		cmd.Println(configInfo)
 		return nil
 	},
 }
 
+// configListCmd represents the config list command
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List connection profiles",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+		return render(display.ProfilesDataset(f, f.Resolve(profileFlag)), false)
+	},
+}
+
+// configUseCmd represents the config use command
+var configUseCmd = &cobra.Command{
+	Use:   "use <profile>",
+	Short: "Make a connection profile the active one",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+		name := args[0]
+		if _, ok := f.Profiles[name]; !ok {
+			return fmt.Errorf("profile %q not found in the config (available: %v)", name, f.Names())
+		}
+		f.ActiveProfile = name
+		if _, err := config.SaveFile(f); err != nil {
+			return err
+		}
+		cmd.Printf("Switched to profile %q\n", name)
+		if env := os.Getenv(config.ProfileEnv); env != "" && env != name {
+			fmt.Fprintf(os.Stderr, "Note: %s=%s overrides the active profile in this shell.\n", config.ProfileEnv, env)
+		}
+		return nil
+	},
+}
+
+// configRemoveCmd represents the config remove command
+var configRemoveCmd = &cobra.Command{
+	Use:   "remove <profile>",
+	Short: "Remove a connection profile",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		f, err := loadConfigFile()
+		if err != nil {
+			return err
+		}
+		name := args[0]
+		if _, ok := f.Profiles[name]; !ok {
+			fmt.Fprintln(os.Stderr, "No profile found with the specified name. No remove performed.")
+			return nil
+		}
+		delete(f.Profiles, name)
+		if f.ActiveProfile == name {
+			f.ActiveProfile = ""
+			// A single remaining profile takes over
+			if names := f.Names(); len(names) == 1 {
+				f.ActiveProfile = names[0]
+			}
+		}
+		if _, err := config.SaveFile(f); err != nil {
+			return err
+		}
+		cmd.Printf("Profile %q removed successfully\n", name)
+		if f.ActiveProfile == "" && len(f.Profiles) > 0 {
+			fmt.Fprintln(os.Stderr, "No profile is active now. Run `jobtracker config use <profile>` to select one.")
+		}
+		return nil
+	},
+}
+
+// configKeyringCmd represents the config keyring command
+var configKeyringCmd = &cobra.Command{
+	Use:   "keyring",
+	Short: "Manage the password of the profile in the OS keyring",
+}
+
+// configKeyringSetCmd represents the config keyring set command
+var configKeyringSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Store the password of the profile in the OS keyring and use it",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		if cfg.DriverName() == config.DriverSQLite {
+			return fmt.Errorf("SQLite databases do not need a password")
+		}
+		fmt.Print("Postgres password: ")
+		password, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return err
+		}
+		if err := cfg.SetKeyringPassword(string(password)); err != nil {
+			return fmt.Errorf("failed to store password in keyring: %w", err)
+		}
+		cfg.Keyring = true
+		if _, err := config.SaveConfig(cfg.Profile, cfg); err != nil {
+			return err
+		}
+		cmd.Printf("Password of profile %q stored in the keyring\n", cfg.Profile)
+		return nil
+	},
+}
+
+// configKeyringRemoveCmd represents the config keyring remove command
+var configKeyringRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove the password of the profile from the OS keyring",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+		removed, err := cfg.DeleteKeyringPassword()
+		if err != nil {
+			return fmt.Errorf("failed to remove password from keyring: %w", err)
+		}
+		cfg.Keyring = false
+		if _, err := config.SaveConfig(cfg.Profile, cfg); err != nil {
+			return err
+		}
+		if !removed {
+			fmt.Fprintln(os.Stderr, "No password found in the keyring for the profile. No remove performed.")
+			return nil
+		}
+		cmd.Printf("Password of profile %q removed from the keyring\n", cfg.Profile)
+		return nil
+	},
+}
+
+// loadConfigFile loads the config file with all profiles
+func loadConfigFile() (*config.File, error) {
+	f, err := config.LoadFile()
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("Config file not found. Run `jobtracker configure` first")
+	}
+	return f, err
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configListCmd, configUseCmd, configRemoveCmd, configKeyringCmd)
+	configKeyringCmd.AddCommand(configKeyringSetCmd, configKeyringRemoveCmd)
 }
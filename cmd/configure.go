@@ -7,49 +7,254 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
 	"github.com/spolivin/jobtracker/v2/internal/db/config"
 )
 
+var configureYes bool
+var configureTest bool
+
 var configureCmd = &cobra.Command{
 	Use:   "configure",
 	Short: "Configure database connection",
+	Long: `Configure the database connection of a profile.
+
+Fields given as flags are not asked for. With --yes, nothing is asked at all
+and the fields not given as flags take their default values, e.g.:
+
+  jobtracker configure --host db.example.com --user app --dbname jobs --yes --test`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		reader := bufio.NewReader(os.Stdin)
+		p := &configurePrompter{cmd: cmd, reader: bufio.NewReader(os.Stdin), interactive: !configureYes}
+		// Settings which are only taken from flags, never asked for
+		flagsOnly := &configurePrompter{cmd: cmd}
+		existing, err := config.LoadConfig(profileFlag)
+		if err != nil {
+			existing = nil
+		}
 
-		ask := func(label, def string) string {
-			fmt.Printf("%s [%s]: ", label, def)
-			v, _ := reader.ReadString('\n')
-			v = strings.TrimSpace(v)
-			if v == "" {
-				return def
-			}
-			return v
+		driver, err := p.ask("driver", "Database driver (postgres or sqlite)", config.DriverPostgres, func(v string) error {
+			return config.ValidateDriver(strings.ToLower(v))
+		})
+		if err != nil {
+			return err
 		}
 
-		portStr := ask("Postgres port", "5432")
-		port, _ := strconv.Atoi(portStr)
+		var cfg *config.ConnectionConfig
+		// Session settings only apply to Postgres, but are kept in SQLite profiles
+		session := p
+		switch strings.ToLower(driver) {
+		case config.DriverPostgres:
+			cfg = &config.ConnectionConfig{}
+			if cfg.URL, err = p.ask("url", "Postgres URL or DSN (optional, e.g. postgres://user@host:5432/db?sslmode=verify-full)", "", func(v string) error {
+				if v == "" {
+					return nil
+				}
+				return config.ValidateDSN(v)
+			}); err != nil {
+				return err
+			}
+			// A URL or DSN carries the connection and TLS settings by itself
+			tls, sslmode := p, config.DefaultSSLMode
+			if cfg.URL != "" {
+				tls, sslmode = flagsOnly, ""
+			} else if err := p.askConnection(cfg); err != nil {
+				return err
+			}
+			if cfg.SSLMode, err = tls.ask("sslmode", "SSL mode ("+strings.Join(config.SSLModes, ", ")+")", sslmode, config.ValidateSSLMode); err != nil {
+				return err
+			}
+			if cfg.SSLMode == "" || cfg.SSLMode == "disable" {
+				tls = flagsOnly
+			}
+			if err := tls.askCertificates(cfg); err != nil {
+				return err
+			}
+		case config.DriverSQLite:
+			defaultPath, err := config.DefaultSQLitePath()
+			if err != nil {
+				return err
+			}
+			cfg = &config.ConnectionConfig{Driver: config.DriverSQLite}
+			session = flagsOnly
+			if cfg.DBPath, err = p.ask("path", "SQLite database file", defaultPath, func(v string) error {
+				return config.ValidateRequired("database file", v)
+			}); err != nil {
+				return err
+			}
+		}
+		if err := session.askSession(cfg, existing); err != nil {
+			return err
+		}
+		// Keeping the status workflow, follow-up rules, migration and password settings from the existing profile
+		if existing != nil {
+			cfg.Profile = existing.Profile
+			cfg.Workflow = existing.Workflow
+			cfg.FollowUp = existing.FollowUp
+			cfg.AutoMigrate = existing.AutoMigrate
+			cfg.PasswordCommand = existing.PasswordCommand
+			cfg.Keyring = existing.Keyring
+		}
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration, nothing saved:\n%w", err)
+		}
 
-		cfg := &config.ConnectionConfig{
-			DBHost: ask("Postgres host", "localhost"),
-			DBPort: port,
-			DBUser: ask("Postgres user", "postgres"),
-			DBName: ask("Database name", "postgres"),
+		if configureTest {
+			if err := testConnection(cmd, cfg); err != nil {
+				return fmt.Errorf("connection test failed, nothing saved: %w", err)
+			}
+			cmd.Printf("Connection to %s succeeded\n", cfg.Target())
 		}
 
-		path, err := config.SaveConfig(cfg)
+		path, err := config.SaveConfig(profileFlag, cfg)
 		if err != nil {
 			return err
 		}
 
-		cmd.Printf("Configuration saved to %s\n\n", path)
+		cmd.Printf("Configuration of profile %q saved to %s\n\n", cfg.Profile, path)
 		return nil
 	},
 }
 
+// configurePrompter asks for the settings of a connection, taking the ones given as flags
+type configurePrompter struct {
+	cmd         *cobra.Command
+	reader      *bufio.Reader
+	interactive bool
+}
+
+// ask returns the value of a setting given as a flag, failing if it is invalid.
+// Otherwise, the user is asked for the value again until it is valid,
+// or the default value is taken when not running interactively.
+func (p *configurePrompter) ask(flag, label, def string, validate func(string) error) (string, error) {
+	if p.cmd.Flags().Changed(flag) {
+		value, _ := p.cmd.Flags().GetString(flag)
+		value = strings.TrimSpace(value)
+		return value, validate(value)
+	}
+	if !p.interactive {
+		return def, validate(def)
+	}
+	for {
+		if def == "" {
+			fmt.Printf("%s: ", label)
+		} else {
+			fmt.Printf("%s [%s]: ", label, def)
+		}
+		value, readErr := p.reader.ReadString('\n')
+		value = strings.TrimSpace(value)
+		if value == "" {
+			value = def
+		}
+		err := validate(value)
+		if err == nil {
+			return value, nil
+		}
+		// Without more input, there is nothing to ask again with
+		if readErr != nil {
+			return "", err
+		}
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+}
+
+// askConnection asks for the host, port, user and database of a Postgres connection
+func (p *configurePrompter) askConnection(cfg *config.ConnectionConfig) error {
+	var err error
+	if cfg.DBHost, err = p.ask("host", "Postgres host", "localhost", func(v string) error {
+		return config.ValidateRequired("host", v)
+	}); err != nil {
+		return err
+	}
+	port, err := p.ask("port", "Postgres port", "5432", func(v string) error {
+		_, err := config.ParsePort(v)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	cfg.DBPort, _ = config.ParsePort(port)
+	if cfg.DBUser, err = p.ask("user", "Postgres user", "postgres", func(v string) error {
+		return config.ValidateRequired("user", v)
+	}); err != nil {
+		return err
+	}
+	cfg.DBName, err = p.ask("dbname", "Database name", "postgres", func(v string) error {
+		return config.ValidateRequired("database name", v)
+	})
+	return err
+}
+
+// askCertificates asks for the optional certificate files of a TLS connection
+func (p *configurePrompter) askCertificates(cfg *config.ConnectionConfig) error {
+	var err error
+	if cfg.SSLRootCert, err = p.ask("sslrootcert", "Root CA certificate file (optional)", "", func(v string) error {
+		return config.ValidateFile("sslrootcert", v)
+	}); err != nil {
+		return err
+	}
+	if cfg.SSLCert, err = p.ask("sslcert", "Client certificate file (optional)", "", func(v string) error {
+		return config.ValidateFile("sslcert", v)
+	}); err != nil {
+		return err
+	}
+	cfg.SSLKey, err = p.ask("sslkey", "Client key file (optional)", "", config.ValidateKeyFile)
+	return err
+}
+
+// askSession asks for the application name and timeouts of a Postgres connection,
+// offering the ones of the existing profile (if any) as defaults
+func (p *configurePrompter) askSession(cfg *config.ConnectionConfig, existing *config.ConnectionConfig) error {
+	if existing == nil {
+		existing = &config.ConnectionConfig{}
+	}
+	var err error
+	if cfg.ApplicationName, err = p.ask("application-name", "Application name reported to the server (optional, default "+config.DefaultApplicationName+")", existing.ApplicationName, config.ValidateApplicationName); err != nil {
+		return err
+	}
+	if cfg.ConnectTimeout, err = p.ask("connect-timeout", "Connect timeout (optional, e.g. 10s, default "+config.DefaultConnectTimeout.String()+")", existing.ConnectTimeout, func(v string) error {
+		return config.ValidateTimeout("connect_timeout", v)
+	}); err != nil {
+		return err
+	}
+	cfg.StatementTimeout, err = p.ask("statement-timeout", "Statement timeout (optional, e.g. 30s, default set by the server)", existing.StatementTimeout, func(v string) error {
+		return config.ValidateTimeout("statement_timeout", v)
+	})
+	return err
+}
+
+// testConnection connects to the configured database and closes the connection
+func testConnection(cmd *cobra.Command, cfg *config.ConnectionConfig) error {
+	password, err := config.GetPassword(cfg)
+	if err != nil {
+		return err
+	}
+	conn, err := db.Connect(cmd.Context(), cfg, password)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 func init() {
 	rootCmd.AddCommand(configureCmd)
+
+	configureCmd.Flags().String("driver", "", "Database driver (postgres or sqlite)")
+	configureCmd.Flags().String("path", "", "SQLite database file")
+	configureCmd.Flags().String("url", "", "Postgres URL or DSN, used instead of host, port, user and dbname")
+	configureCmd.Flags().String("host", "", "Postgres host")
+	configureCmd.Flags().String("port", "", "Postgres port")
+	configureCmd.Flags().String("user", "", "Postgres user")
+	configureCmd.Flags().String("dbname", "", "Database name")
+	configureCmd.Flags().String("sslmode", "", "SSL mode ("+strings.Join(config.SSLModes, ", ")+")")
+	configureCmd.Flags().String("sslrootcert", "", "Root CA certificate file")
+	configureCmd.Flags().String("sslcert", "", "Client certificate file")
+	configureCmd.Flags().String("sslkey", "", "Client key file")
+	configureCmd.Flags().String("application-name", "", "Application name reported to the Postgres server (default "+config.DefaultApplicationName+")")
+	configureCmd.Flags().String("connect-timeout", "", "Maximum time to establish a connection, e.g. 10s (default "+config.DefaultConnectTimeout.String()+")")
+	configureCmd.Flags().String("statement-timeout", "", "Maximum time of a single statement, e.g. 30s (default: server setting)")
+	configureCmd.Flags().BoolVarP(&configureYes, "yes", "y", false, "Do not ask for settings, taking the defaults of the ones not given as flags")
+	configureCmd.Flags().BoolVar(&configureTest, "test", false, "Test the connection before saving the configuration")
 }
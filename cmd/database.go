@@ -0,0 +1,121 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"github.com/spolivin/jobtracker/v2/internal/db/migrate"
+)
+
+// databaseAnnotation marks commands working with job applications,
+// which get a connection to a database with an up-to-date schema before they run
+const databaseAnnotation = "jobtracker.database"
+
+// cfg and dbase are the config and the connection shared by the commands marked with databaseAnnotation
+var cfg *config.ConnectionConfig
+var dbase *sql.DB
+
+var autoMigrate bool
+var profileFlag string
+
+// requireDatabase marks commands (along with their subcommands) as working with job applications
+func requireDatabase(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Annotations == nil {
+			cmd.Annotations = map[string]string{}
+		}
+		cmd.Annotations[databaseAnnotation] = "true"
+	}
+}
+
+// requiresDatabase checks if a command or any of its parents is marked with databaseAnnotation
+func requiresDatabase(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[databaseAnnotation] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// loadConfig loads the config of the profile selected with --profile or JOBTRACKER_PROFILE
+func loadConfig() (*config.ConnectionConfig, error) {
+	c, err := config.LoadConfig(profileFlag)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("Config file not found. Run `jobtracker configure` first")
+	}
+	return c, err
+}
+
+// openDatabase loads the config and connects to the configured database
+func openDatabase(ctx context.Context) (*config.ConnectionConfig, *sql.DB, error) {
+	c, err := loadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	password, err := config.GetPassword(c)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := db.Connect(ctx, c, password)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c, conn, nil
+}
+
+// prepareDatabase opens the shared connection and makes sure that the schema is up to date
+func prepareDatabase(cmd *cobra.Command) error {
+	var err error
+	cfg, dbase, err = openDatabase(cmd.Context())
+	if err != nil {
+		return err
+	}
+	return checkSchema(cmd.Context(), dbase, autoMigrate || cfg.AutoMigrate)
+}
+
+// checkSchema compares the applied migrations with the embedded ones,
+// applying the pending migrations if apply is set and failing otherwise
+func checkSchema(ctx context.Context, conn *sql.DB, apply bool) error {
+	statuses, err := migrate.Statuses(ctx, conn)
+	if err != nil {
+		return err
+	}
+	c := migrate.Classify(statuses)
+	if len(c.Modified) > 0 {
+		return &migrate.DriftError{Versions: c.Modified}
+	}
+	if len(c.Unknown) > 0 {
+		fmt.Fprintf(os.Stderr, "Warning: the database has migrations unknown to this version of jobtracker (%s). Consider upgrading.\n", strings.Join(c.Unknown, ", "))
+	}
+	if len(c.Pending) == 0 {
+		return nil
+	}
+	if !apply {
+		return fmt.Errorf("Database schema is out of date, pending migrations: %s. Run `jobtracker migrate` first or pass --auto-migrate", strings.Join(c.Pending, ", "))
+	}
+
+	steps, err := migrate.Up(ctx, conn, migrate.Options{})
+	for _, step := range steps {
+		fmt.Fprintf(os.Stderr, "Migration %s: %s\n", step.Migration.Version, step.Direction)
+	}
+	return err
+}
+
+// closeDatabase closes the shared connection, if it was opened
+func closeDatabase() {
+	if dbase != nil {
+		dbase.Close()
+	}
+}
@@ -9,7 +9,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spolivin/jobtracker/v2/internal/db"
-	"github.com/spolivin/jobtracker/v2/internal/db/config"
 )
 
 var deleteId int
@@ -19,33 +18,10 @@ var deleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a job application by ID",
 	RunE: func(cmd *cobra.Command, args []string) error {
-
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
-		}
-
-		password, err := config.GetPassword()
-		if err != nil {
-			return err
-		}
 		ctx := cmd.Context()
 
-		dbase, err := db.Connect(ctx, cfg, password)
-		if err != nil {
-			return err
-		}
-		defer dbase.Close()
-		// Check if 'applications' table exists in Postgres
-		tableExists, err := db.CheckTableExists(ctx, dbase, "applications")
-		if err != nil {
-			return err
-		}
-		if !tableExists {
-			return fmt.Errorf("Delete cannot proceed: table 'applications' does not exist")
-		}
 		// Delete the job application from the database
-		store := db.NewJobApplicationStore(dbase)
+		store := db.NewStore(dbase)
 		rowsAffected, err := store.Delete(ctx, deleteId)
 		if err != nil {
 			return err
@@ -61,6 +37,7 @@ var deleteCmd = &cobra.Command{
 
 func init() {
 	rootCmd.AddCommand(deleteCmd)
+	requireDatabase(deleteCmd)
 
 	deleteCmd.Flags().IntVarP(&deleteId, "id", "i", 0, "Job application ID to delete")
 	deleteCmd.MarkFlagRequired("id")
@@ -0,0 +1,41 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+	"github.com/spolivin/jobtracker/v2/internal/doctor"
+)
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnose the configuration and the database connection",
+	Long: `Diagnose the configuration and the database connection of the selected profile.
+
+The config file, the profile, DNS and TCP reachability of the server, authentication,
+the server version, TLS, the applications table and the migrations are checked in turn,
+with a hint on fixing every problem found. The exit status is non-zero if any check fails.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		checks := doctor.Run(cmd.Context(), doctor.Options{Profile: profileFlag})
+		if err := render(display.ChecksDataset(checks), false); err != nil {
+			return err
+		}
+		if failed := doctor.Failures(checks); failed > 0 {
+			fmt.Fprintln(os.Stderr, "Follow the hints above and run `jobtracker doctor` again.")
+			// The checklist already explains the failures, the usage would only bury it
+			cmd.SilenceUsage = true
+			return &exitError{err: fmt.Errorf("%d check(s) failed", failed)}
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
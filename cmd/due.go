@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+)
+
+var dueFilter filterFlags
+var dueWithin string
+var dueSnooze int
+var dueSnoozeFor string
+var dueDone int
+
+// dueCmd represents the due command
+var dueCmd = &cobra.Command{
+	Use:   "due",
+	Short: "List overdue and upcoming follow-ups of job applications",
+	Long: `List overdue and upcoming follow-ups of job applications, the most overdue first.
+
+A follow-up is due on the date set with "update --follow-up" or, failing that, by the rule of the status
+of the application, e.g. 7 days after it was moved to Applied with no change since. Rules are set in the
+"follow_up" section of the config and default to 7d for Applied and Screening and 5d for Interview.
+
+--done marks the follow-up of an application as done, restarting the rule of its status from now.
+--snooze postpones it by --for from now.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		store := db.NewStore(dbase)
+
+		if dueDone != 0 || dueSnooze != 0 {
+			if dueDone != 0 && dueSnooze != 0 {
+				return fmt.Errorf("--done and --snooze cannot be used together")
+			}
+			var err error
+			if dueDone != 0 {
+				err = store.CompleteFollowUp(ctx, dueDone)
+			} else {
+				var d time.Duration
+				if d, err = db.ParseDuration(dueSnoozeFor); err != nil {
+					return err
+				}
+				err = store.ScheduleFollowUp(ctx, dueSnooze, time.Now().Add(d))
+			}
+			if errors.Is(err, db.ErrNotFound) {
+				fmt.Fprintln(os.Stderr, "No job application found with the specified ID.")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if dueDone != 0 {
+				cmd.Println("Follow-up marked as done")
+			} else {
+				cmd.Printf("Follow-up snoozed by %s\n", dueSnoozeFor)
+			}
+			return nil
+		}
+
+		within, err := db.ParseDuration(dueWithin)
+		if err != nil {
+			return err
+		}
+		filter, err := dueFilter.filter()
+		if err != nil {
+			return err
+		}
+		workflow, err := db.NewWorkflow(cfg.Workflow)
+		if err != nil {
+			return err
+		}
+		rules, err := db.NewFollowUpRules(cfg.FollowUp, workflow)
+		if err != nil {
+			return err
+		}
+		apps, err := store.Read(ctx, "id", false, filter)
+		if err != nil {
+			return err
+		}
+		states, err := store.ReadFollowUps(ctx)
+		if err != nil {
+			return err
+		}
+
+		horizon := time.Now().Add(within)
+		var due []db.FollowUp
+		for _, followUp := range db.ScheduleFollowUps(apps, states, rules, workflow) {
+			if followUp.DueAt.After(horizon) {
+				break
+			}
+			due = append(due, followUp)
+		}
+		if len(due) == 0 && !renderEmpty() {
+			fmt.Fprintf(os.Stderr, "No follow-ups due within %s.\n", dueWithin)
+			return nil
+		}
+		return render(display.FollowUpsDataset(due), false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(dueCmd)
+	requireDatabase(dueCmd)
+	dueFilter.register(dueCmd)
+
+	dueCmd.Flags().StringVarP(&dueWithin, "within", "w", "7d", "List follow-ups due within the duration from now (e.g. 0d for overdue only)")
+	dueCmd.Flags().IntVar(&dueDone, "done", 0, "Mark the follow-up of the application with the ID as done")
+	dueCmd.Flags().IntVar(&dueSnooze, "snooze", 0, "Postpone the follow-up of the application with the ID")
+	dueCmd.Flags().StringVar(&dueSnoozeFor, "for", "3d", "Duration to postpone the follow-up by with --snooze (e.g. 3d, 1w, 12h)")
+}
+
+// parseFollowUp parses the date of a follow-up, given as a date or as a duration from now
+func parseFollowUp(value string) (time.Time, error) {
+	if t, err := db.ParseDate(value, false); err == nil {
+		return t, nil
+	}
+	d, err := db.ParseDuration(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid follow-up: %q (examples: 2026-05-01, 5d, 2w)", value)
+	}
+	return time.Now().Add(d), nil
+}
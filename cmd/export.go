@@ -9,47 +9,27 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spolivin/jobtracker/v2/internal/db"
-	"github.com/spolivin/jobtracker/v2/internal/db/config"
 	"github.com/spolivin/jobtracker/v2/internal/exporter"
 )
 
 var exportFormat string
 var exportFilename string
+var exportFilter filterFlags
 
 // exportCmd represents the export command
 var exportCmd = &cobra.Command{
 	Use:   "export",
 	Short: "Export job applications to a specified format (e.g., CSV, JSON)",
 	RunE: func(cmd *cobra.Command, args []string) error {
-
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
-		}
-
-		password, err := config.GetPassword()
-		if err != nil {
-			return err
-		}
 		ctx := cmd.Context()
 
-		dbase, err := db.Connect(ctx, cfg, password)
-		if err != nil {
-			return err
-		}
-		defer dbase.Close()
-
-		// Check if 'applications' table exists in Postgres
-		tableExists, err := db.CheckTableExists(ctx, dbase, "applications")
+		filter, err := exportFilter.filter()
 		if err != nil {
 			return err
 		}
-		if !tableExists {
-			return fmt.Errorf("Export cannot proceed: table 'applications' does not exist.")
-		}
-		// Read all job applications from the database
-		store := db.NewJobApplicationStore(dbase)
-		rows, err := store.Read(ctx, "", false)
+		// Read the matching job applications from the database
+		store := db.NewStore(dbase)
+		rows, err := store.Read(ctx, "", false, filter)
 		if err != nil {
 			return err
 		}
@@ -57,17 +37,32 @@ var exportCmd = &cobra.Command{
 			fmt.Fprintln(os.Stderr, "Nothing to export: no job applications found in the database.")
 			return nil
 		}
+		notes, err := store.ReadAllNotes(ctx)
+		if err != nil {
+			return err
+		}
+		notes = notesOf(rows, notes)
 		// Export data based on the specified format
 		switch exportFormat {
 		case "json":
-			if err := exporter.ExportToJson(rows, exportFilename + "." + exportFormat); err != nil {
+			// Notes are nested into their job applications
+			db.AttachNotes(rows, notes)
+			if err := exporter.ExportToJson(rows, exportFilename+"."+exportFormat); err != nil {
 				return err
 			}
 
 		case "csv":
-			if err := exporter.ExportToCsv(rows, exportFilename + "." + exportFormat); err != nil {
+			if err := exporter.ExportToCsv(rows, exportFilename+"."+exportFormat); err != nil {
 				return err
 			}
+			// Notes are written to a separate file
+			if len(notes) > 0 {
+				notesFilename := exportFilename + "_notes." + exportFormat
+				if err := exporter.ExportNotesToCsv(notes, notesFilename); err != nil {
+					return err
+				}
+				cmd.Printf("Notes exported to %s\n", notesFilename)
+			}
 		default:
 			return fmt.Errorf("unsupported export format: %s", exportFormat)
 		}
@@ -76,12 +71,30 @@ var exportCmd = &cobra.Command{
 	},
 }
 
+// notesOf returns the notes belonging to the given job applications.
+func notesOf(apps []db.JobApplication, notes []db.Note) []db.Note {
+	ids := make(map[int]bool, len(apps))
+	for _, app := range apps {
+		ids[app.ID] = true
+	}
+	var selected []db.Note
+	for _, note := range notes {
+		if ids[note.ApplicationID] {
+			selected = append(selected, note)
+		}
+	}
+	return selected
+}
+
 func init() {
 	rootCmd.AddCommand(exportCmd)
+	requireDatabase(exportCmd)
 
 	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", "", "Export format (json or csv)")
 	exportCmd.Flags().StringVarP(&exportFilename, "output", "o", "exported_data", "Output filename (without extension)")
 
+	exportFilter.register(exportCmd)
+
 	exportCmd.MarkFlagRequired("format")
 
 }
@@ -0,0 +1,65 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+// filterFlags holds the flags selecting a subset of job applications.
+type filterFlags struct {
+	statuses     []string
+	company      string
+	since        string
+	until        string
+	updatedSince string
+	stale        string
+	tags         []string
+	anyTag       bool
+}
+
+// register adds the filter flags to a command.
+func (f *filterFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringSliceVar(&f.statuses, "status", nil, "Only applications in the status (repeatable or comma-separated)")
+	cmd.Flags().StringVar(&f.company, "company", "", "Only applications whose company contains the text")
+	cmd.Flags().StringVar(&f.since, "since", "", "Only applications created on or after the date (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().StringVar(&f.until, "until", "", "Only applications created on or before the date (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().StringVar(&f.updatedSince, "updated-since", "", "Only applications updated on or after the date (YYYY-MM-DD or RFC 3339)")
+	cmd.Flags().StringVar(&f.stale, "stale", "", "Only applications not updated for the duration (e.g. 14d, 2w, 36h)")
+	cmd.Flags().StringSliceVarP(&f.tags, "tag", "t", nil, "Only applications with the tag (repeatable or comma-separated)")
+	cmd.Flags().BoolVar(&f.anyTag, "any", false, "Match applications with any of the tags instead of all of them")
+}
+
+// filter compiles the flags into a store filter.
+func (f *filterFlags) filter() (db.Filter, error) {
+	filter := db.Filter{
+		Statuses: f.statuses,
+		Company:  f.company,
+		Tags:     f.tags,
+		AnyTag:   f.anyTag,
+	}
+	var err error
+	if f.since != "" {
+		if filter.Since, err = db.ParseDate(f.since, false); err != nil {
+			return filter, err
+		}
+	}
+	if f.until != "" {
+		if filter.Until, err = db.ParseDate(f.until, true); err != nil {
+			return filter, err
+		}
+	}
+	if f.updatedSince != "" {
+		if filter.UpdatedSince, err = db.ParseDate(f.updatedSince, false); err != nil {
+			return filter, err
+		}
+	}
+	if f.stale != "" {
+		if filter.Stale, err = db.ParseDuration(f.stale); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
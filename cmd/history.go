@@ -0,0 +1,43 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+)
+
+var historyId int
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the status history of a job application",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		store := db.NewStore(dbase)
+		changes, err := store.History(ctx, historyId)
+		if err != nil {
+			return err
+		}
+		if len(changes) == 0 && !renderEmpty() {
+			fmt.Fprintln(os.Stderr, "No status history found for the specified ID.")
+			return nil
+		}
+		return render(display.HistoryDataset(changes), false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	requireDatabase(historyCmd)
+
+	historyCmd.Flags().IntVarP(&historyId, "id", "i", 0, "Job application ID")
+	historyCmd.MarkFlagRequired("id")
+}
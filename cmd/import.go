@@ -0,0 +1,121 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/importer"
+)
+
+var importFile string
+var importFormat string
+var importKeepIds bool
+var importKeepTimestamps bool
+var importDryRun bool
+var importForce bool
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import job applications from a CSV or JSON file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Detect format from file extension if not specified
+		format := strings.ToLower(importFormat)
+		if format == "" {
+			format = strings.TrimPrefix(strings.ToLower(filepath.Ext(importFile)), ".")
+		}
+
+		// Read records from the file
+		var records []importer.Record
+		var rowErrors []importer.RowError
+		var err error
+		switch format {
+		case "json":
+			records, rowErrors, err = importer.ImportFromJson(importFile)
+		case "csv":
+			records, rowErrors, err = importer.ImportFromCsv(importFile)
+		default:
+			return fmt.Errorf("unsupported import format: %q (use --format json or csv)", format)
+		}
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+
+		workflow, err := db.NewWorkflow(cfg.Workflow)
+		if err != nil {
+			return err
+		}
+		store := db.NewStore(dbase)
+		store.SetWorkflow(workflow)
+
+		// Validate records against the workflow and existing IDs
+		existingIds := make(map[int]bool)
+		if importKeepIds {
+			rows, err := store.Read(ctx, "", false, db.Filter{})
+			if err != nil {
+				return err
+			}
+			for _, row := range rows {
+				existingIds[row.ID] = true
+			}
+		}
+		valid, invalid := importer.Validate(records, workflow, importForce, importKeepIds, existingIds)
+		rowErrors = append(rowErrors, invalid...)
+
+		if importDryRun {
+			reportRowErrors(rowErrors)
+			cmd.Printf("Dry run: %d job applications would be imported, %d rows skipped.\n", len(valid), len(rowErrors))
+			return nil
+		}
+
+		// Insert valid records one by one so that a bad row does not abort the import
+		opts := db.ImportOptions{KeepIDs: importKeepIds, KeepTimestamps: importKeepTimestamps, Force: importForce}
+		imported := 0
+		for _, rec := range valid {
+			if _, err := store.Insert(ctx, rec.Application, opts); err != nil {
+				rowErrors = append(rowErrors, importer.RowError{Row: rec.Row, Err: err})
+				continue
+			}
+			imported++
+		}
+		if importKeepIds && imported > 0 {
+			if err := store.SyncIDSequence(ctx); err != nil {
+				return err
+			}
+		}
+		reportRowErrors(rowErrors)
+		cmd.Printf("Imported %d job applications, %d rows skipped.\n", imported, len(rowErrors))
+		return nil
+	},
+}
+
+// reportRowErrors prints per-row import errors to stderr ordered by row.
+func reportRowErrors(rowErrors []importer.RowError) {
+	sort.Slice(rowErrors, func(i, j int) bool { return rowErrors[i].Row < rowErrors[j].Row })
+	for _, rowErr := range rowErrors {
+		fmt.Fprintln(os.Stderr, rowErr.Error())
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	requireDatabase(importCmd)
+
+	importCmd.Flags().StringVar(&importFile, "file", "", "File to import")
+	importCmd.Flags().StringVarP(&importFormat, "format", "f", "", "Import format (json or csv); detected from file extension by default")
+	importCmd.Flags().BoolVar(&importKeepIds, "keep-ids", false, "Preserve IDs from the file instead of assigning new ones")
+	importCmd.Flags().BoolVar(&importKeepTimestamps, "keep-timestamps", false, "Preserve creation and update timestamps from the file")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "Validate the file and print a summary without importing")
+	importCmd.Flags().BoolVar(&importForce, "force", false, "Allow statuses outside of the configured workflow")
+
+	importCmd.MarkFlagRequired("file")
+}
@@ -9,59 +9,109 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spolivin/jobtracker/v2/internal/db"
-	"github.com/spolivin/jobtracker/v2/internal/db/config"
 	"github.com/spolivin/jobtracker/v2/internal/display"
 )
 
+// defaultPageSize is the page size used by --page when --limit is not set
+const defaultPageSize = 20
+
 var sortBy string
 var descending bool
+var listFilter filterFlags
+var listLimit int
+var listOffset int
+var listPage int
+var listAfter string
+var listNoPager bool
 
 var listCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List all job applications",
+	Short: "List job applications, optionally filtered",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 
-		cfg, err := config.LoadConfig()
+		filter, err := listFilter.filter()
 		if err != nil {
-			return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
+			return err
 		}
-
-		password, err := config.GetPassword()
+		page, err := listPageFromFlags(cmd)
 		if err != nil {
 			return err
 		}
-
-		ctx := cmd.Context()
-
-		dbase, err := db.Connect(ctx, cfg, password)
+		store := db.NewStore(dbase)
+		rows, more, err := store.ReadPage(ctx, sortBy, descending, filter, page)
 		if err != nil {
 			return err
 		}
-		defer dbase.Close()
-		// Check if 'applications' table exists in Postgres
-		tableExists, err := db.CheckTableExists(ctx, dbase, "applications")
+		total, err := store.Count(ctx, filter)
 		if err != nil {
 			return err
 		}
-		if !tableExists {
-			return fmt.Errorf("List cannot proceed: table 'applications' does not exist.")
+		if len(rows) == 0 && !renderEmpty() {
+			switch {
+			case total > 0:
+				fmt.Fprintln(os.Stderr, "No job applications found on the specified page.")
+			case !filter.IsZero():
+				fmt.Fprintln(os.Stderr, "No job applications match the specified filters.")
+			default:
+				fmt.Fprintln(os.Stderr, "Table is empty: no job applications found in the database.")
+			}
+			return nil
 		}
 
-		store := db.NewJobApplicationStore(dbase)
-		rows, err := store.Read(ctx, sortBy, descending)
-		if err != nil {
+		// The position of a keyset page is unknown
+		offset := page.Offset
+		if page.After != nil {
+			offset = -1
+		}
+		if err := render(display.ApplicationsDataset(rows, offset, total), !listNoPager); err != nil {
 			return err
 		}
-		if len(rows) == 0 {
-			fmt.Fprintln(os.Stderr, "Table is empty: no job applications found in the database.")
-			return nil
+		if more {
+			next := db.CursorOf(rows[len(rows)-1], sortBy)
+			fmt.Fprintf(os.Stderr, "More results available: rerun with --after %s\n", next)
 		}
-		return display.RenderTable(rows)
+		return nil
 	},
 }
 
+// listPageFromFlags builds the page selected by the pagination flags.
+func listPageFromFlags(cmd *cobra.Command) (db.Page, error) {
+	page := db.Page{Limit: listLimit, Offset: listOffset}
+	if cmd.Flags().Changed("page") {
+		if cmd.Flags().Changed("offset") {
+			return page, fmt.Errorf("--page and --offset cannot be used together")
+		}
+		if listPage < 1 {
+			return page, fmt.Errorf("--page must be at least 1")
+		}
+		if page.Limit == 0 {
+			page.Limit = defaultPageSize
+		}
+		page.Offset = (listPage - 1) * page.Limit
+	}
+	if listAfter != "" {
+		if cmd.Flags().Changed("page") || cmd.Flags().Changed("offset") {
+			return page, fmt.Errorf("--after cannot be used together with --page or --offset")
+		}
+		cursor, err := db.ParseCursor(listAfter)
+		if err != nil {
+			return page, err
+		}
+		page.After = &cursor
+	}
+	return page, nil
+}
+
 func init() {
 	rootCmd.AddCommand(listCmd)
+	requireDatabase(listCmd)
 	listCmd.Flags().StringVarP(&sortBy, "sort", "s", "", "Sort job applications by field")
 	listCmd.Flags().BoolVarP(&descending, "desc", "d", false, "Sort in descending order")
+	listFilter.register(listCmd)
+	listCmd.Flags().IntVarP(&listLimit, "limit", "l", 0, "Maximum number of job applications to show (0 for all)")
+	listCmd.Flags().IntVar(&listOffset, "offset", 0, "Number of job applications to skip")
+	listCmd.Flags().IntVar(&listPage, "page", 0, fmt.Sprintf("Page number to show (%d per page unless --limit is set)", defaultPageSize))
+	listCmd.Flags().StringVar(&listAfter, "after", "", "Show the job applications after the cursor printed by the previous page")
+	listCmd.Flags().BoolVar(&listNoPager, "no-pager", false, "Do not pipe long output into a pager")
 }
@@ -4,46 +4,128 @@ Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
 package cmd
 
 import (
+	"database/sql"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
-	"github.com/spolivin/jobtracker/v2/internal/db"
-	"github.com/spolivin/jobtracker/v2/internal/db/config"
 	"github.com/spolivin/jobtracker/v2/internal/db/migrate"
+	"github.com/spolivin/jobtracker/v2/internal/display"
 )
 
+var migrateDryRun bool
+var migrateSteps int
+
 // migrateCmd represents the migrate command
 var migrateCmd = &cobra.Command{
 	Use:   "migrate",
 	Short: "Run database migrations",
 	RunE: func(cmd *cobra.Command, args []string) error {
+		return withMigrationsDB(cmd, func(dbase *sql.DB) error {
+			steps, err := migrate.Up(cmd.Context(), dbase, migrateOptions())
+			return reportMigrationSteps(cmd, steps, err)
+		})
+	},
+}
 
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
-		}
+// migrateStatusCmd represents the migrate status command
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "List applied and pending migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withMigrationsDB(cmd, func(dbase *sql.DB) error {
+			statuses, err := migrate.Statuses(cmd.Context(), dbase)
+			if err != nil {
+				return err
+			}
+			return render(display.MigrationsDataset(statuses), false)
+		})
+	},
+}
 
-		password, err := config.GetPassword()
-		if err != nil {
-			return err
-		}
-		ctx := cmd.Context()
+// migrateVerifyCmd represents the migrate verify command
+var migrateVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that applied migrations match their embedded scripts",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withMigrationsDB(cmd, func(dbase *sql.DB) error {
+			if err := migrate.Verify(cmd.Context(), dbase); err != nil {
+				return err
+			}
+			cmd.Println("Applied migrations match their embedded scripts")
+			return nil
+		})
+	},
+}
 
-		dbase, err := db.Connect(ctx, cfg, password)
-		if err != nil {
-			return err
-		}
-		defer dbase.Close()
+// migrateDownCmd represents the migrate down command
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert the latest applied migrations",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withMigrationsDB(cmd, func(dbase *sql.DB) error {
+			steps, err := migrate.Down(cmd.Context(), dbase, migrateSteps, migrateOptions())
+			return reportMigrationSteps(cmd, steps, err)
+		})
+	},
+}
+
+// migrateToCmd represents the migrate to command
+var migrateToCmd = &cobra.Command{
+	Use:   "to <version>",
+	Short: "Migrate up or down to a version (e.g. 002 or 002_status_history, 0 to revert all)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withMigrationsDB(cmd, func(dbase *sql.DB) error {
+			steps, err := migrate.To(cmd.Context(), dbase, args[0], migrateOptions())
+			return reportMigrationSteps(cmd, steps, err)
+		})
+	},
+}
 
-		if err := migrate.Run(ctx, dbase); err != nil {
-			return err
+// migrateOptions returns the options selected with the migrate flags.
+func migrateOptions() migrate.Options {
+	return migrate.Options{DryRun: migrateDryRun, Out: os.Stdout}
+}
+
+// reportMigrationSteps prints the migration steps that were executed.
+func reportMigrationSteps(cmd *cobra.Command, steps []migrate.Step, err error) error {
+	if migrateDryRun {
+		if err == nil && len(steps) == 0 {
+			fmt.Fprintln(os.Stderr, "Dry run: database schema is up to date, nothing to execute.")
 		}
-		cmd.Println("Migrations run successfully")
+		return err
+	}
+	for _, step := range steps {
+		cmd.Printf("Migration %s: %s\n", step.Migration.Version, step.Direction)
+	}
+	if err != nil {
+		return err
+	}
+	if len(steps) == 0 {
+		cmd.Println("Database schema is up to date, no migrations to run")
 		return nil
+	}
+	cmd.Println("Migrations run successfully")
+	return nil
+}
 
-	},
+// withMigrationsDB connects to the database and runs fn with the connection.
+// Migration commands work with outdated schemas, so they open their own connection.
+func withMigrationsDB(cmd *cobra.Command, fn func(dbase *sql.DB) error) error {
+	_, dbase, err := openDatabase(cmd.Context())
+	if err != nil {
+		return err
+	}
+	defer dbase.Close()
+
+	return fn(dbase)
 }
 
 func init() {
 	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateStatusCmd, migrateVerifyCmd, migrateDownCmd, migrateToCmd)
+
+	migrateCmd.PersistentFlags().BoolVar(&migrateDryRun, "dry-run", false, "Print the SQL of the migrations without executing it")
+	migrateDownCmd.Flags().IntVar(&migrateSteps, "steps", 1, "Number of migrations to revert")
 }
@@ -0,0 +1,120 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+)
+
+var noteAppId int
+var noteId int
+var noteText string
+
+// noteCmd represents the note command
+var noteCmd = &cobra.Command{
+	Use:   "note",
+	Short: "Manage notes attached to job applications",
+}
+
+// noteAddCmd represents the note add command
+var noteAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Attach a note to a job application",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := db.NewStore(dbase)
+		id, err := store.AddNote(cmd.Context(), noteAppId, noteText)
+		if err != nil {
+			return err
+		}
+		if id == 0 {
+			fmt.Fprintln(os.Stderr, "No job application found with the specified ID. No note added.")
+			return nil
+		}
+		cmd.Println(fmt.Sprintf("Note with ID %d added successfully", id))
+		return nil
+	},
+}
+
+// noteListCmd represents the note list command
+var noteListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List notes of a job application",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := db.NewStore(dbase)
+		notes, err := store.ReadNotes(cmd.Context(), noteAppId)
+		if err != nil {
+			return err
+		}
+		if len(notes) == 0 && !renderEmpty() {
+			fmt.Fprintln(os.Stderr, "No notes found for the specified ID.")
+			return nil
+		}
+		return render(display.NotesDataset(notes), false)
+	},
+}
+
+// noteEditCmd represents the note edit command
+var noteEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Replace the text of a note",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := db.NewStore(dbase)
+		rowsAffected, err := store.UpdateNote(cmd.Context(), noteId, noteText)
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			fmt.Fprintln(os.Stderr, "No note found with the specified ID. No update performed.")
+			return nil
+		}
+		cmd.Println("Note updated successfully")
+		return nil
+	},
+}
+
+// noteDeleteCmd represents the note delete command
+var noteDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a note by ID",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := db.NewStore(dbase)
+		rowsAffected, err := store.DeleteNote(cmd.Context(), noteId)
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			fmt.Fprintln(os.Stderr, "No note found with the specified ID. No delete performed.")
+			return nil
+		}
+		cmd.Println(fmt.Sprintf("Note with ID %d deleted successfully", noteId))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(noteCmd)
+	requireDatabase(noteCmd)
+	noteCmd.AddCommand(noteAddCmd, noteListCmd, noteEditCmd, noteDeleteCmd)
+
+	noteAddCmd.Flags().IntVarP(&noteAppId, "id", "i", 0, "Job application ID")
+	noteAddCmd.Flags().StringVarP(&noteText, "text", "t", "", "Note text")
+	noteAddCmd.MarkFlagRequired("id")
+	noteAddCmd.MarkFlagRequired("text")
+
+	noteListCmd.Flags().IntVarP(&noteAppId, "id", "i", 0, "Job application ID")
+	noteListCmd.MarkFlagRequired("id")
+
+	noteEditCmd.Flags().IntVarP(&noteId, "note", "n", 0, "Note ID")
+	noteEditCmd.Flags().StringVarP(&noteText, "text", "t", "", "New note text")
+	noteEditCmd.MarkFlagRequired("note")
+	noteEditCmd.MarkFlagRequired("text")
+
+	noteDeleteCmd.Flags().IntVarP(&noteId, "note", "n", 0, "Note ID")
+	noteDeleteCmd.MarkFlagRequired("note")
+}
@@ -0,0 +1,87 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+	"golang.org/x/term"
+)
+
+var outputFlag string
+var formatFlag string
+var templateFlag string
+
+// outputFormat is the output format selected with --output, validated before a command runs
+var outputFormat = display.FormatTable
+
+// outputTemplate is the template selected with --format or --template, if any
+var outputTemplate *template.Template
+
+// parseOutputFlags validates the output flags inherited by a command.
+// Commands may define local flags with the same names and another meaning (e.g. export --output).
+func parseOutputFlags(cmd *cobra.Command) error {
+	inherited := cmd.InheritedFlags()
+	if inherited.Lookup("output") != nil {
+		format, err := display.ParseFormat(outputFlag)
+		if err != nil {
+			return err
+		}
+		outputFormat = format
+	}
+	text := ""
+	if inherited.Lookup("format") != nil {
+		text = formatFlag
+	}
+	if inherited.Lookup("template") != nil && templateFlag != "" {
+		if text != "" {
+			return fmt.Errorf("--format and --template cannot be used together")
+		}
+		dir, err := config.TemplatesDir()
+		if err != nil {
+			return err
+		}
+		if text, err = display.LoadTemplate(dir, templateFlag); err != nil {
+			return err
+		}
+	}
+	if text == "" {
+		return nil
+	}
+	if inherited.Lookup("output") != nil && cmd.Flags().Changed("output") && outputFormat != display.FormatTable {
+		return fmt.Errorf("--output %s cannot be combined with a format template", outputFormat)
+	}
+	// Colors are only written to terminals, see https://no-color.org
+	_, noColor := os.LookupEnv("NO_COLOR")
+	colors := !noColor && term.IsTerminal(int(os.Stdout.Fd()))
+	tmpl, err := display.ParseTemplate(text, colors)
+	if err != nil {
+		return err
+	}
+	outputTemplate = tmpl
+	return nil
+}
+
+// render writes a dataset to stdout in the selected output format or template.
+// Tables are shown in a pager if paged is set.
+func render(d display.Dataset, paged bool) error {
+	if outputTemplate != nil {
+		return display.RenderTemplate(os.Stdout, outputTemplate, d.Records)
+	}
+	if outputFormat == display.FormatTable && paged {
+		return display.Paged(d.Table)
+	}
+	return display.Render(os.Stdout, outputFormat, d)
+}
+
+// renderEmpty reports whether empty results should still be rendered,
+// so that scripts get an empty document instead of a message on stderr.
+func renderEmpty() bool {
+	return outputFormat != display.FormatTable
+}
@@ -4,14 +4,56 @@ Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
 package cmd
 
 import (
+	"errors"
+	"os"
+
 	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"github.com/spolivin/jobtracker/v2/internal/display"
 )
 
 var rootCmd = &cobra.Command{
 	Use:   "jobtracker",
 	Short: "Job tracker CLI for tracking job applications",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := parseOutputFlags(cmd); err != nil {
+			return err
+		}
+		if requiresDatabase(cmd) {
+			return prepareDatabase(cmd)
+		}
+		return nil
+	},
 }
 
 func Execute() {
-	rootCmd.Execute()
+	err := rootCmd.Execute()
+	closeDatabase()
+	// Cobra has already printed the error, commands reporting their outcome
+	// through the exit status tell scripts about it
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		os.Exit(1)
+	}
+}
+
+// exitError is a command error that makes jobtracker exit with a non-zero status.
+type exitError struct {
+	err error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&outputFlag, "output", string(display.FormatTable), "Output format of read-style commands (table, json, ndjson, csv, tsv, yaml)")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", "", "Go template applied to every record of read-style commands, e.g. '{{.ID}} {{.Company}}'")
+	rootCmd.PersistentFlags().StringVar(&templateFlag, "template", "", "Name of a template file (<name>.tmpl) in the templates config directory")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Connection profile to use (overrides "+config.ProfileEnv+" and the active profile)")
+	rootCmd.PersistentFlags().BoolVar(&autoMigrate, "auto-migrate", false, "Apply pending database migrations before running the command")
 }
@@ -9,7 +9,6 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spolivin/jobtracker/v2/internal/db"
-	"github.com/spolivin/jobtracker/v2/internal/db/config"
 	"github.com/spolivin/jobtracker/v2/internal/display"
 )
 
@@ -20,47 +19,25 @@ var searchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Search job applications by keyword",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := config.LoadConfig()
-		if err != nil {
-			return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
-		}
-
-		password, err := config.GetPassword()
-		if err != nil {
-			return err
-		}
 		ctx := cmd.Context()
 
-		dbase, err := db.Connect(ctx, cfg, password)
-		if err != nil {
-			return err
-		}
-		defer dbase.Close()
-
-		// Check if 'applications' table exists in Postgres
-		tableExists, err := db.CheckTableExists(ctx, dbase, "applications")
-		if err != nil {
-			return err
-		}
-		if !tableExists {
-			return fmt.Errorf("Search cannot proceed: table 'applications' does not exist.")
-		}
 		// Search job applications in the database
-		store := db.NewJobApplicationStore(dbase)
+		store := db.NewStore(dbase)
 		rows, err := store.Search(ctx, keyword)
 		if err != nil {
 			return err
 		}
-		if len(rows) == 0 {
+		if len(rows) == 0 && !renderEmpty() {
 			fmt.Fprintln(os.Stderr, "No data found matching the keyword.")
 			return nil
 		}
-		return display.RenderTable(rows)
+		return render(display.ApplicationsDataset(rows, 0, len(rows)), true)
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(searchCmd)
+	requireDatabase(searchCmd)
 
 	searchCmd.Flags().StringVarP(&keyword, "keyword", "k", "", "Keyword to search for")
 	searchCmd.MarkFlagRequired("keyword")
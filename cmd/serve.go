@@ -0,0 +1,87 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/server"
+)
+
+var serveAddr string
+var serveToken string
+var serveQuiet bool
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve job applications over a local REST API and web dashboard",
+	Long: `Serve job applications over a local REST API, documented by the OpenAPI document at /api/v1/openapi.json,
+along with a web dashboard at / to filter, chart, add and edit applications.
+
+Requests authenticate with an API token sent as "Authorization: Bearer <token>". The token is taken from
+--token or the ` + server.TokenEnv + ` environment variable; otherwise a random one is generated and printed.
+The server stops on Ctrl+C (SIGINT) or SIGTERM, letting in-flight requests finish.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		token := serveToken
+		if token == "" {
+			token = os.Getenv(server.TokenEnv)
+		}
+		generated := token == ""
+		if generated {
+			var err error
+			if token, err = server.GenerateToken(); err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "API token: %s\n", token)
+		}
+
+		workflow, err := db.NewWorkflow(cfg.Workflow)
+		if err != nil {
+			return err
+		}
+		store := db.NewStore(dbase)
+		store.SetWorkflow(workflow)
+
+		opts := server.Options{Token: token}
+		if !serveQuiet {
+			opts.Logger = log.New(os.Stderr, "", log.LstdFlags)
+		}
+		ln, err := net.Listen("tcp", serveAddr)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Serving the API on http://%s (press Ctrl+C to stop)\n", ln.Addr())
+		// A generated token is handed to the dashboard in the fragment of the link, which browsers do not send
+		dashboardURL := fmt.Sprintf("http://%s/", ln.Addr())
+		if generated {
+			dashboardURL += "#token=" + token
+		}
+		fmt.Fprintf(os.Stderr, "Dashboard: %s\n", dashboardURL)
+		if err := server.Serve(ctx, ln, server.New(store, opts)); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "Server stopped")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	requireDatabase(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:8080", "Address to listen on")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "API token expected from clients (default: $"+server.TokenEnv+" or a generated one)")
+	serveCmd.Flags().BoolVarP(&serveQuiet, "quiet", "q", false, "Do not log requests")
+}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+)
+
+var showId int
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:   "show [company]",
+	Short: "Show a job application in full detail",
+	Long: `Show a job application with its notes and status history.
+
+The application is selected by ID with --id, or by company name. Company names
+match loosely (case, punctuation, abbreviations and small typos are tolerated),
+and several equally good matches are listed to pick one by ID.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		if (showId == 0) == (len(args) == 0) {
+			return fmt.Errorf("specify either --id or a company name")
+		}
+		store := db.NewStore(dbase)
+		id := showId
+		if len(args) == 1 {
+			apps, err := store.Read(ctx, "", false, db.Filter{})
+			if err != nil {
+				return err
+			}
+			matches := db.MatchCompany(apps, args[0])
+			switch len(matches) {
+			case 0:
+				fmt.Fprintln(os.Stderr, "No job application found matching the company name.")
+				return nil
+			case 1:
+				id = matches[0].ID
+			default:
+				fmt.Fprintf(os.Stderr, "Several job applications match %q. Run `jobtracker show --id <ID>` with one of them:\n", args[0])
+				return display.RenderTable(os.Stderr, matches, 0, len(matches))
+			}
+		}
+
+		app, err := store.Get(ctx, id)
+		if errors.Is(err, db.ErrNotFound) {
+			fmt.Fprintln(os.Stderr, "No job application found with the specified ID.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if app.Notes, err = store.ReadNotes(ctx, id); err != nil {
+			return err
+		}
+		history, err := store.History(ctx, id)
+		if err != nil {
+			return err
+		}
+		return render(display.DetailsDataset(db.ApplicationDetails{JobApplication: app, History: history}), false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(showCmd)
+	requireDatabase(showCmd)
+
+	showCmd.Flags().IntVarP(&showId, "id", "i", 0, "Job application ID")
+}
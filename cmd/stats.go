@@ -0,0 +1,57 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+	"github.com/spolivin/jobtracker/v2/internal/stats"
+)
+
+var statsJson bool
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show pipeline funnel and response-rate analytics",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+
+		workflow, err := db.NewWorkflow(cfg.Workflow)
+		if err != nil {
+			return err
+		}
+		store := db.NewStore(dbase)
+		rows, err := store.Read(ctx, "", false, db.Filter{})
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			fmt.Fprintln(os.Stderr, "Table is empty: no job applications found in the database.")
+			return nil
+		}
+		history, err := store.ReadHistory(ctx)
+		if err != nil {
+			return err
+		}
+
+		report := stats.Compute(rows, history, workflow)
+		// --json is kept as a shorthand for --output json
+		if statsJson {
+			outputFormat = display.FormatJSON
+		}
+		return render(display.StatsDataset(report), false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	requireDatabase(statsCmd)
+
+	statsCmd.Flags().BoolVar(&statsJson, "json", false, "Output statistics as JSON (same as --output json)")
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+)
+
+var tagAppId int
+var tagNames []string
+var tagFrom string
+var tagTo string
+
+// tagCmd represents the tag command
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Manage tags of job applications",
+}
+
+// tagAddCmd represents the tag add command
+var tagAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Attach tags to a job application",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := db.NewStore(dbase)
+		added, err := store.AddTags(cmd.Context(), tagAppId, tagNames)
+		if errors.Is(err, db.ErrNotFound) {
+			fmt.Fprintln(os.Stderr, "No job application found with the specified ID. No tags added.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		cmd.Println(fmt.Sprintf("%d tag(s) attached successfully", added))
+		return nil
+	},
+}
+
+// tagRemoveCmd represents the tag remove command
+var tagRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Detach tags from a job application, or delete them entirely if no ID is given",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := db.NewStore(dbase)
+		ctx := cmd.Context()
+		if !cmd.Flags().Changed("id") {
+			// Deleting the tags from all applications
+			var deleted int64
+			for _, name := range tagNames {
+				n, err := store.DeleteTag(ctx, name)
+				if err != nil {
+					return err
+				}
+				deleted += n
+			}
+			if deleted == 0 {
+				fmt.Fprintln(os.Stderr, "No tags found with the specified names. No delete performed.")
+				return nil
+			}
+			cmd.Println(fmt.Sprintf("%d tag(s) deleted successfully", deleted))
+			return nil
+		}
+		removed, err := store.RemoveTags(ctx, tagAppId, tagNames)
+		if errors.Is(err, db.ErrNotFound) {
+			fmt.Fprintln(os.Stderr, "No job application found with the specified ID. No tags removed.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		cmd.Println(fmt.Sprintf("%d tag(s) detached successfully", removed))
+		return nil
+	},
+}
+
+// tagRenameCmd represents the tag rename command
+var tagRenameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename a tag, merging it into an existing tag with the new name",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := db.NewStore(dbase)
+		renamed, err := store.RenameTag(cmd.Context(), tagFrom, tagTo)
+		if err != nil {
+			return err
+		}
+		if renamed == 0 {
+			fmt.Fprintln(os.Stderr, "No tag found with the specified name. No rename performed.")
+			return nil
+		}
+		cmd.Println("Tag renamed successfully")
+		return nil
+	},
+}
+
+// tagListCmd represents the tag list command
+var tagListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all tags with the number of applications",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		store := db.NewStore(dbase)
+		tags, err := store.ReadTags(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if len(tags) == 0 && !renderEmpty() {
+			fmt.Fprintln(os.Stderr, "No tags found in the database.")
+			return nil
+		}
+		return render(display.TagsDataset(tags), false)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tagCmd)
+	requireDatabase(tagCmd)
+	tagCmd.AddCommand(tagAddCmd, tagRemoveCmd, tagRenameCmd, tagListCmd)
+
+	tagAddCmd.Flags().IntVarP(&tagAppId, "id", "i", 0, "Job application ID")
+	tagAddCmd.Flags().StringSliceVarP(&tagNames, "tag", "t", nil, "Tag name (repeatable or comma-separated)")
+	tagAddCmd.MarkFlagRequired("id")
+	tagAddCmd.MarkFlagRequired("tag")
+
+	tagRemoveCmd.Flags().IntVarP(&tagAppId, "id", "i", 0, "Job application ID (omit to delete the tags entirely)")
+	tagRemoveCmd.Flags().StringSliceVarP(&tagNames, "tag", "t", nil, "Tag name (repeatable or comma-separated)")
+	tagRemoveCmd.MarkFlagRequired("tag")
+
+	tagRenameCmd.Flags().StringVar(&tagFrom, "from", "", "Current tag name")
+	tagRenameCmd.Flags().StringVar(&tagTo, "to", "", "New tag name")
+	tagRenameCmd.MarkFlagRequired("from")
+	tagRenameCmd.MarkFlagRequired("to")
+}
@@ -0,0 +1,49 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/tui"
+	"golang.org/x/term"
+)
+
+// tuiCmd represents the tui command
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse and edit job applications in a full-screen interface",
+	Long: `Browse and edit job applications in a full-screen interface.
+
+Keys:
+  ↑/↓, j/k, pgup/pgdown  move the selection
+  /                      search incrementally (enter keeps, esc clears the search)
+  1-6                    sort by a column, again to reverse the order
+  enter                  show or hide the details of the selected application
+  s                      change the status of the selected application
+  a                      add an application
+  d                      delete the selected application
+  r                      reload
+  q                      quit`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+			return fmt.Errorf("tui needs an interactive terminal, use `jobtracker list` instead")
+		}
+		workflow, err := db.NewWorkflow(cfg.Workflow)
+		if err != nil {
+			return err
+		}
+		store := db.NewStore(dbase)
+		store.SetWorkflow(workflow)
+		return tui.Run(cmd.Context(), store)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+	requireDatabase(tuiCmd)
+}
@@ -4,84 +4,115 @@ Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spolivin/jobtracker/v2/internal/db"
-	"github.com/spolivin/jobtracker/v2/internal/db/config"
 )
 
 var updateId int
 var updateCompany string
 var updatePosition string
 var updateStatus string
+var updateForce bool
+var updateTags []string
+var updateUntags []string
+var updateFollowUp string
 
 // updateCmd represents the update command
 var updateCmd = &cobra.Command{
-       Use:   "update",
-       Short: "Update fields of a job application",
-       RunE: func(cmd *cobra.Command, args []string) error {
-	       cfg, err := config.LoadConfig()
-	       if err != nil {
-		       return fmt.Errorf("Config file not found. Run `jobtracker configure` first")
-	       }
+	Use:   "update",
+	Short: "Update fields of a job application",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
 
-	       password, err := config.GetPassword()
-	       if err != nil {
-		       return err
-	       }
-	       ctx := cmd.Context()
-
-	       dbase, err := db.Connect(ctx, cfg, password)
-	       if err != nil {
-		       return err
-	       }
-	       defer dbase.Close()
-	       // Check if 'applications' table exists in Postgres
-	       tableExists, err := db.CheckTableExists(ctx, dbase, "applications")
-	       if err != nil {
-		       return err
-	       }
-	       if !tableExists {
-		       return fmt.Errorf("Update cannot proceed: table 'applications' does not exist.")
-	       }
-	       // Build fields to update
-	       fields := make(map[string]string)
-	       if updateCompany != "" {
-		       fields["company"] = updateCompany
-	       }
-	       if updatePosition != "" {
-		       fields["position"] = updatePosition
-	       }
-	       if updateStatus != "" {
-		       fields["status"] = updateStatus
-	       }
-	       if len(fields) == 0 {
-		       return fmt.Errorf("No fields specified to update. Use --company, --position, or --status.")
-	       }
-	       // Update the job application in the database
-	       store := db.NewJobApplicationStore(dbase)
-	       rowsAffected, err := store.Update(ctx, updateId, fields)
-	       if err != nil {
-		       return err
-	       }
-	       if rowsAffected == 0 {
-		       fmt.Fprintln(os.Stderr, "No job application found with the specified ID. No update performed.")
-		       return nil
-	       }
-	       cmd.Println("Job application updated successfully")
-	       return nil
-       },
+		// Build fields to update
+		fields := make(map[string]string)
+		if updateCompany != "" {
+			fields["company"] = updateCompany
+		}
+		if updatePosition != "" {
+			fields["position"] = updatePosition
+		}
+		if updateStatus != "" {
+			fields["status"] = updateStatus
+		}
+		if len(fields) == 0 && len(updateTags) == 0 && len(updateUntags) == 0 && updateFollowUp == "" {
+			return fmt.Errorf("No fields specified to update. Use --company, --position, --status, --tag, --untag or --follow-up.")
+		}
+		var followUpAt time.Time
+		if updateFollowUp != "" {
+			var err error
+			if followUpAt, err = parseFollowUp(updateFollowUp); err != nil {
+				return err
+			}
+		}
+		// Update the job application in the database
+		workflow, err := db.NewWorkflow(cfg.Workflow)
+		if err != nil {
+			return err
+		}
+		store := db.NewStore(dbase)
+		store.SetWorkflow(workflow)
+		if len(fields) > 0 {
+			rowsAffected, err := store.Update(ctx, updateId, fields, updateForce)
+			if err != nil {
+				return err
+			}
+			if rowsAffected == 0 {
+				fmt.Fprintln(os.Stderr, "No job application found with the specified ID. No update performed.")
+				return nil
+			}
+		}
+		// Attach and detach tags
+		if len(updateTags) > 0 {
+			if _, err := store.AddTags(ctx, updateId, updateTags); err != nil {
+				if errors.Is(err, db.ErrNotFound) {
+					fmt.Fprintln(os.Stderr, "No job application found with the specified ID. No update performed.")
+					return nil
+				}
+				return err
+			}
+		}
+		if len(updateUntags) > 0 {
+			if _, err := store.RemoveTags(ctx, updateId, updateUntags); err != nil {
+				if errors.Is(err, db.ErrNotFound) {
+					fmt.Fprintln(os.Stderr, "No job application found with the specified ID. No update performed.")
+					return nil
+				}
+				return err
+			}
+		}
+		// Schedule the next follow-up
+		if updateFollowUp != "" {
+			if err := store.ScheduleFollowUp(ctx, updateId, followUpAt); err != nil {
+				if errors.Is(err, db.ErrNotFound) {
+					fmt.Fprintln(os.Stderr, "No job application found with the specified ID. No update performed.")
+					return nil
+				}
+				return err
+			}
+		}
+		cmd.Println("Job application updated successfully")
+		return nil
+	},
 }
 
 func init() {
 	rootCmd.AddCommand(updateCmd)
+	requireDatabase(updateCmd)
 
 	updateCmd.Flags().IntVarP(&updateId, "id", "i", 0, "Job application ID")
 	updateCmd.Flags().StringVarP(&updateCompany, "company", "c", "", "Job company")
 	updateCmd.Flags().StringVarP(&updatePosition, "position", "p", "", "Job position")
 	updateCmd.Flags().StringVarP(&updateStatus, "status", "s", "", "Job status")
+	updateCmd.Flags().StringSliceVarP(&updateTags, "tag", "t", nil, "Tag to attach (repeatable or comma-separated)")
+	updateCmd.Flags().StringSliceVar(&updateUntags, "untag", nil, "Tag to detach (repeatable or comma-separated)")
+	updateCmd.Flags().StringVar(&updateFollowUp, "follow-up", "", "Date of the next follow-up (YYYY-MM-DD or RFC 3339) or duration from now (e.g. 5d)")
+	updateCmd.Flags().BoolVarP(&updateForce, "force", "f", false, "Skip status workflow validation")
 
 	updateCmd.MarkFlagRequired("id")
 }
@@ -2,19 +2,391 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported database drivers.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// Postgres connection settings.
+const (
+	// DatabaseURLEnv is the environment variable holding a Postgres URL or DSN,
+	// which takes precedence over the connection settings of the profile.
+	DatabaseURLEnv = "JOBTRACKER_DATABASE_URL"
+	// DefaultSSLMode is the SSL mode used when none is configured.
+	DefaultSSLMode = "disable"
+	// DefaultApplicationName identifies the connections of jobtracker on the server.
+	DefaultApplicationName = "jobtracker"
+	// DefaultConnectTimeout is the connect timeout used when none is configured.
+	DefaultConnectTimeout = 15 * time.Second
+)
+
+// SSLModes lists the supported SSL modes of Postgres connections.
+var SSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
 
-	"golang.org/x/term"
+// Profile selection.
+const (
+	// DefaultProfile is the profile used when none is selected.
+	DefaultProfile = "default"
+	// ProfileEnv is the environment variable selecting a profile.
+	ProfileEnv = "JOBTRACKER_PROFILE"
 )
 
+// profileNamePattern restricts profile names to letters, digits, dashes and underscores.
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateProfileName checks that a profile name can be used in the config.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name: %q (use letters, digits, dashes and underscores)", name)
+	}
+	return nil
+}
+
+// Config file holding named connection profiles.
+type File struct {
+	ActiveProfile string                       `json:"active_profile,omitempty"`
+	Profiles      map[string]*ConnectionConfig `json:"profiles"`
+}
+
 // Database connection config.
 type ConnectionConfig struct {
+	// Profile is the name of the profile the config was loaded from.
+	Profile string `json:"-"`
+
+	Driver string `json:"driver,omitempty"`
+	DBPath string `json:"db_path,omitempty"`
 	DBHost string `json:"db_host"`
 	DBPort int    `json:"db_port"`
 	DBUser string `json:"db_user"`
 	DBName string `json:"db_name"`
+
+	// PasswordCommand is a shell command printing the password, e.g. "pass show jobtracker".
+	PasswordCommand string `json:"password_command,omitempty"`
+	// Keyring looks the password up in the OS keyring (Secret Service on Linux).
+	Keyring bool `json:"keyring,omitempty"`
+
+	// URL is a postgres:// URL or a key=value DSN used instead of the fields above.
+	URL string `json:"url,omitempty"`
+	// SSLMode is one of SSLModes, DefaultSSLMode if empty.
+	SSLMode string `json:"sslmode,omitempty"`
+	// SSLRootCert is the path of the root CA certificate verifying the server.
+	SSLRootCert string `json:"sslrootcert,omitempty"`
+	// SSLCert and SSLKey are the paths of the client certificate and its private key.
+	SSLCert string `json:"sslcert,omitempty"`
+	SSLKey  string `json:"sslkey,omitempty"`
+	// ApplicationName is reported to the server, DefaultApplicationName if empty.
+	ApplicationName string `json:"application_name,omitempty"`
+	// ConnectTimeout and StatementTimeout are durations such as "10s" or "1m".
+	ConnectTimeout   string `json:"connect_timeout,omitempty"`
+	StatementTimeout string `json:"statement_timeout,omitempty"`
+
+	// AutoMigrate applies pending migrations before running commands instead of failing.
+	AutoMigrate bool `json:"auto_migrate,omitempty"`
+
+	Workflow *WorkflowConfig `json:"workflow,omitempty"`
+	FollowUp *FollowUpConfig `json:"follow_up,omitempty"`
+}
+
+// Application status workflow config.
+type WorkflowConfig struct {
+	Statuses    []string            `json:"statuses"`
+	Transitions map[string][]string `json:"transitions"`
+}
+
+// Follow-up reminder config.
+type FollowUpConfig struct {
+	Rules []FollowUpRuleConfig `json:"rules"`
+}
+
+// FollowUpRuleConfig schedules a follow-up for applications left in a status
+// without changes for a duration such as "7d" or "2w".
+type FollowUpRuleConfig struct {
+	Status string `json:"status"`
+	After  string `json:"after"`
+}
+
+// DriverName returns the configured database driver, defaulting to Postgres.
+func (c *ConnectionConfig) DriverName() string {
+	if c.Driver == "" {
+		return DriverPostgres
+	}
+	return c.Driver
+}
+
+// Target describes the database the config connects to, without the password.
+func (c *ConnectionConfig) Target() string {
+	if c.DriverName() == DriverSQLite {
+		if c.DBPath != "" {
+			return c.DBPath
+		}
+		path, _ := DefaultSQLitePath()
+		return path
+	}
+	if dsn := c.DatabaseURL(); dsn != "" {
+		return RedactDSN(dsn)
+	}
+	return fmt.Sprintf("%s@%s:%s/%s", c.DBUser, c.DBHost, strconv.Itoa(c.DBPort), c.DBName)
+}
+
+// DatabaseURL returns the Postgres URL or DSN from JOBTRACKER_DATABASE_URL or the config.
+func (c *ConnectionConfig) DatabaseURL() string {
+	if env := os.Getenv(DatabaseURLEnv); env != "" {
+		return env
+	}
+	return c.URL
+}
+
+// Timeouts returns the connect and statement timeouts, using defaults for empty ones.
+// A zero statement timeout leaves the server setting unchanged.
+func (c *ConnectionConfig) Timeouts() (connect, statement time.Duration, err error) {
+	connect = DefaultConnectTimeout
+	if c.ConnectTimeout != "" {
+		if connect, err = parseTimeout("connect_timeout", c.ConnectTimeout); err != nil {
+			return 0, 0, err
+		}
+	}
+	if c.StatementTimeout != "" {
+		if statement, err = parseTimeout("statement_timeout", c.StatementTimeout); err != nil {
+			return 0, 0, err
+		}
+	}
+	return connect, statement, nil
+}
+
+// parseTimeout parses a positive timeout.
+func parseTimeout(name, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid %s: %q (examples: 10s, 1m)", name, value)
+	}
+	return d, nil
+}
+
+// Validate checks the connection settings, reporting every invalid one.
+func (c *ConnectionConfig) Validate() error {
+	if err := ValidateDriver(c.DriverName()); err != nil {
+		return err
+	}
+	if c.DriverName() == DriverSQLite {
+		return nil
+	}
+
+	var errs []error
+	if dsn := c.DatabaseURL(); dsn != "" {
+		errs = append(errs, ValidateDSN(dsn))
+	} else {
+		errs = append(errs,
+			ValidateRequired("host", c.DBHost),
+			ValidatePort(c.DBPort),
+			ValidateRequired("user", c.DBUser),
+			ValidateRequired("database name", c.DBName),
+		)
+	}
+	errs = append(errs,
+		ValidateSSLMode(c.SSLMode),
+		ValidateFile("sslrootcert", c.SSLRootCert),
+		ValidateFile("sslcert", c.SSLCert),
+		ValidateKeyFile(c.SSLKey),
+	)
+	if (c.SSLCert == "") != (c.SSLKey == "") {
+		errs = append(errs, fmt.Errorf("sslcert and sslkey must be set together"))
+	}
+	errs = append(errs,
+		ValidateApplicationName(c.ApplicationName),
+		ValidateTimeout("connect_timeout", c.ConnectTimeout),
+		ValidateTimeout("statement_timeout", c.StatementTimeout),
+	)
+	return errors.Join(errs...)
+}
+
+// ValidateDriver checks that a database driver is supported.
+func ValidateDriver(driver string) error {
+	if driver != DriverPostgres && driver != DriverSQLite {
+		return fmt.Errorf("unsupported database driver: %q (allowed: %s, %s)", driver, DriverPostgres, DriverSQLite)
+	}
+	return nil
+}
+
+// ValidateRequired checks that a setting is not empty.
+func ValidateRequired(name, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return fmt.Errorf("%s cannot be empty", name)
+	}
+	return nil
+}
+
+// ParsePort parses a port number.
+func ParsePort(value string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid port: %q (must be a number between 1 and 65535)", value)
+	}
+	return port, ValidatePort(port)
+}
+
+// ValidatePort checks that a port number is in range.
+func ValidatePort(port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("invalid port: %d (must be between 1 and 65535)", port)
+	}
+	return nil
+}
+
+// ValidateSSLMode checks that an SSL mode is supported, an empty one meaning the default.
+func ValidateSSLMode(mode string) error {
+	if mode != "" && !slices.Contains(SSLModes, mode) {
+		return fmt.Errorf("invalid sslmode: %q (allowed: %s)", mode, strings.Join(SSLModes, ", "))
+	}
+	return nil
+}
+
+// ValidateApplicationName checks that an optional application name is accepted by the server
+// as is: printable ASCII of at most 63 characters.
+func ValidateApplicationName(name string) error {
+	if len(name) > 63 {
+		return fmt.Errorf("invalid application_name: %q (at most 63 characters)", name)
+	}
+	for _, r := range name {
+		if r < ' ' || r > '~' {
+			return fmt.Errorf("invalid application_name: %q (printable ASCII characters only)", name)
+		}
+	}
+	return nil
+}
+
+// ValidateTimeout checks that an optional timeout is a positive duration.
+func ValidateTimeout(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	_, err := parseTimeout(name, value)
+	return err
+}
+
+// ValidateFile checks that an optional file exists.
+func ValidateFile(name, path string) error {
+	if path == "" {
+		return nil
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return nil
+}
+
+// ValidateKeyFile checks that an optional private key exists and is only accessible by its owner.
+func ValidateKeyFile(path string) error {
+	if err := ValidateFile("sslkey", path); err != nil || path == "" {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err == nil && runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("invalid sslkey: %s must not be accessible by group or others (chmod 600)", path)
+	}
+	return err
+}
+
+// ValidateDSN checks that a Postgres connection string is a URL or a key=value DSN.
+func ValidateDSN(dsn string) error {
+	if !strings.Contains(dsn, "://") {
+		if !strings.Contains(dsn, "=") {
+			return fmt.Errorf("invalid connection string: expected a postgres:// URL or key=value pairs")
+		}
+		return nil
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return fmt.Errorf("invalid connection URL: %w", err)
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return fmt.Errorf("invalid connection URL: scheme must be postgres or postgresql, got %q", u.Scheme)
+	}
+	return nil
+}
+
+// dsnPasswordPattern matches the password of a key=value DSN.
+var dsnPasswordPattern = regexp.MustCompile(`(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// hasURLPassword checks if the Postgres URL or DSN carries a password.
+func (c *ConnectionConfig) hasURLPassword() bool {
+	dsn := c.DatabaseURL()
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		if err != nil || u.User == nil {
+			return false
+		}
+		_, ok := u.User.Password()
+		return ok
+	}
+	return dsnPasswordPattern.MatchString(dsn)
+}
+
+// RedactDSN hides the password of a Postgres URL or DSN.
+func RedactDSN(dsn string) string {
+	if strings.Contains(dsn, "://") {
+		if u, err := url.Parse(dsn); err == nil {
+			return u.Redacted()
+		}
+	}
+	return dsnPasswordPattern.ReplaceAllString(dsn, "${1}xxxxx")
+}
+
+// Names returns the names of the profiles in alphabetical order.
+func (f *File) Names() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the name of the selected profile: the given name if set,
+// otherwise the one from JOBTRACKER_PROFILE, the active profile or the default one.
+func (f *File) Resolve(name string) string {
+	if name != "" {
+		return name
+	}
+	if env := os.Getenv(ProfileEnv); env != "" {
+		return env
+	}
+	if f.ActiveProfile != "" {
+		return f.ActiveProfile
+	}
+	return DefaultProfile
+}
+
+// DefaultSQLitePath returns the default location of the SQLite database file.
+func DefaultSQLitePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jobtracker", "jobtracker.db"), nil
+}
+
+// TemplatesDir returns the directory holding named output templates.
+func TemplatesDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jobtracker", "templates"), nil
 }
 
 // get_config_path retrieves a path to database connection config.
@@ -26,8 +398,14 @@ func get_config_path() (string, error) {
 	return filepath.Join(dir, "jobtracker", "config.json"), nil
 }
 
-// LoadConfig loads config for connection to the database.
-func LoadConfig() (*ConnectionConfig, error) {
+// FilePath returns the location of the config file.
+func FilePath() (string, error) {
+	return get_config_path()
+}
+
+// LoadFile loads the config file with all profiles.
+// Files written by older releases hold a single connection, which becomes the default profile.
+func LoadFile() (*File, error) {
 	p, err := get_config_path()
 	if err != nil {
 		return nil, err
@@ -38,16 +416,28 @@ func LoadConfig() (*ConnectionConfig, error) {
 		return nil, err
 	}
 
-	var c ConnectionConfig
-	if err := json.Unmarshal(data, &c); err != nil {
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
 		return nil, err
 	}
-
-	return &c, nil
+	if f.Profiles == nil {
+		var c ConnectionConfig
+		if err := json.Unmarshal(data, &c); err != nil {
+			return nil, err
+		}
+		f = File{ActiveProfile: DefaultProfile, Profiles: map[string]*ConnectionConfig{DefaultProfile: &c}}
+	}
+	for name, c := range f.Profiles {
+		if c == nil {
+			return nil, fmt.Errorf("profile %q is empty", name)
+		}
+		c.Profile = name
+	}
+	return &f, nil
 }
 
-// SaveConfig saves a new config to a default path.
-func SaveConfig(c *ConnectionConfig) (string, error) {
+// SaveFile saves the config file with all profiles to a default path.
+func SaveFile(f *File) (string, error) {
 	p, err := get_config_path()
 	if err != nil {
 		return "", err
@@ -57,22 +447,51 @@ func SaveConfig(c *ConnectionConfig) (string, error) {
 		return "", err
 	}
 
-	data, _ := json.MarshalIndent(c, "", "  ")
+	data, _ := json.MarshalIndent(f, "", "  ")
 	return p, os.WriteFile(p, data, 0600)
 }
 
-// promptPassword prompts for a user to enter password to Postgres.
-func promptPassword() (string, error) {
-	fmt.Print("Postgres password: ")
-	b, err := term.ReadPassword(int(os.Stdin.Fd()))
-	fmt.Println()
-	return string(b), err
+// LoadConfig loads config for connection to the database from a profile.
+// An empty name selects the profile as described in File.Resolve.
+// Without a config file, JOBTRACKER_DATABASE_URL alone can be used.
+func LoadConfig(profile string) (*ConnectionConfig, error) {
+	f, err := LoadFile()
+	if os.IsNotExist(err) && os.Getenv(DatabaseURLEnv) != "" {
+		// The connection string from the environment is enough without a config file
+		return &ConnectionConfig{Profile: profile}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	name := f.Resolve(profile)
+	c, ok := f.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in the config (available: %v)", name, f.Names())
+	}
+	return c, nil
 }
 
-// GetPassword retrieves Postgres password from environment variable or prompts the user.
-func GetPassword() (string, error) {
-	if os.Getenv("DB_PASS") == "" {
-		return promptPassword()
+// SaveConfig saves a new config to a profile, keeping the other profiles.
+// The first saved profile becomes the active one.
+func SaveConfig(profile string, c *ConnectionConfig) (string, error) {
+	f, err := LoadFile()
+	if os.IsNotExist(err) {
+		f, err = &File{}, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if f.Profiles == nil {
+		f.Profiles = map[string]*ConnectionConfig{}
+	}
+	name := f.Resolve(profile)
+	if err := ValidateProfileName(name); err != nil {
+		return "", err
+	}
+	c.Profile = name
+	f.Profiles[name] = c
+	if f.ActiveProfile == "" {
+		f.ActiveProfile = name
 	}
-	return os.Getenv("DB_PASS"), nil
+	return SaveFile(f)
 }
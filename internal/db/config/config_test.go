@@ -0,0 +1,201 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// useConfigDir points the config directory to a temporary one
+func useConfigDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv(ProfileEnv, "")
+	t.Setenv(DatabaseURLEnv, "")
+	return filepath.Join(dir, "jobtracker", "config.json")
+}
+
+func TestLoadLegacyConfig(t *testing.T) {
+	path := useConfigDir(t)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		t.Fatal(err)
+	}
+	legacy := `{"db_host": "localhost", "db_port": 5432, "db_user": "postgres", "db_name": "jobs"}`
+	if err := os.WriteFile(path, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if c.Profile != DefaultProfile || c.DBHost != "localhost" || c.DBName != "jobs" {
+		t.Errorf("LoadConfig() = %+v, want the legacy connection as the default profile", c)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	useConfigDir(t)
+
+	if _, err := SaveConfig("", &ConnectionConfig{Driver: DriverSQLite, DBPath: "personal.db"}); err != nil {
+		t.Fatalf("SaveConfig() error = %v", err)
+	}
+	if _, err := SaveConfig("team", &ConnectionConfig{DBHost: "db.example.com", DBPort: 5432}); err != nil {
+		t.Fatalf("SaveConfig(team) error = %v", err)
+	}
+	if _, err := SaveConfig("bad name", &ConnectionConfig{}); err == nil {
+		t.Error("SaveConfig() with invalid profile name should return error")
+	}
+
+	f, err := LoadFile()
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if f.ActiveProfile != DefaultProfile || len(f.Profiles) != 2 {
+		t.Errorf("LoadFile() = %+v, want two profiles with the first one active", f)
+	}
+
+	tests := []struct {
+		name    string
+		profile string
+		env     string
+		want    string
+	}{
+		{"active profile", "", "", DefaultProfile},
+		{"environment", "", "team", "team"},
+		{"flag overrides environment", DefaultProfile, "team", DefaultProfile},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(ProfileEnv, tt.env)
+			c, err := LoadConfig(tt.profile)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if c.Profile != tt.want {
+				t.Errorf("LoadConfig() profile = %q, want %q", c.Profile, tt.want)
+			}
+		})
+	}
+
+	if _, err := LoadConfig("missing"); err == nil {
+		t.Error("LoadConfig() with unknown profile should return error")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Setenv(DatabaseURLEnv, "")
+	dir := t.TempDir()
+	cert := filepath.Join(dir, "client.crt")
+	key := filepath.Join(dir, "client.key")
+	openKey := filepath.Join(dir, "open.key")
+	for path, mode := range map[string]os.FileMode{cert: 0644, key: 0600, openKey: 0644} {
+		if err := os.WriteFile(path, []byte("test"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fields := ConnectionConfig{DBHost: "localhost", DBPort: 5432, DBUser: "postgres", DBName: "jobs"}
+	with := func(change func(c *ConnectionConfig)) ConnectionConfig {
+		c := fields
+		change(&c)
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		cfg     ConnectionConfig
+		wantErr string
+	}{
+		{"fields", fields, ""},
+		{"sqlite", ConnectionConfig{Driver: DriverSQLite}, ""},
+		{"unknown driver", ConnectionConfig{Driver: "mysql"}, "unsupported database driver"},
+		{"invalid port", with(func(c *ConnectionConfig) { c.DBPort = 0 }), "invalid port"},
+		{"empty host", with(func(c *ConnectionConfig) { c.DBHost = " " }), "host cannot be empty"},
+		{"URL", ConnectionConfig{URL: "postgresql://app@db/jobs?sslmode=verify-full"}, ""},
+		{"DSN", ConnectionConfig{URL: "host=db dbname=jobs"}, ""},
+		{"URL with other scheme", ConnectionConfig{URL: "mysql://db/jobs"}, "scheme must be postgres"},
+		{"malformed connection string", ConnectionConfig{URL: "db.example.com"}, "invalid connection string"},
+		{"invalid sslmode", with(func(c *ConnectionConfig) { c.SSLMode = "on" }), "invalid sslmode"},
+		{"client certificate", with(func(c *ConnectionConfig) { c.SSLMode = "verify-full"; c.SSLCert = cert; c.SSLKey = key }), ""},
+		{"missing root certificate", with(func(c *ConnectionConfig) { c.SSLRootCert = filepath.Join(dir, "root.crt") }), "invalid sslrootcert"},
+		{"certificate without key", with(func(c *ConnectionConfig) { c.SSLCert = cert }), "must be set together"},
+		{"readable key", with(func(c *ConnectionConfig) { c.SSLCert = cert; c.SSLKey = openKey }), "chmod 600"},
+		{"timeouts", with(func(c *ConnectionConfig) { c.ConnectTimeout = "10s"; c.StatementTimeout = "1m" }), ""},
+		{"invalid timeout", with(func(c *ConnectionConfig) { c.StatementTimeout = "-1s" }), "invalid statement_timeout"},
+		{"invalid connect timeout", with(func(c *ConnectionConfig) { c.ConnectTimeout = "10" }), "invalid connect_timeout"},
+		{"application name", with(func(c *ConnectionConfig) { c.ApplicationName = "jobtracker-laptop" }), ""},
+		{"non-ASCII application name", with(func(c *ConnectionConfig) { c.ApplicationName = "jobtracker\n" }), "invalid application_name"},
+		{"long application name", with(func(c *ConnectionConfig) { c.ApplicationName = strings.Repeat("a", 64) }), "at most 63 characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePort(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"5432", 5432, false},
+		{" 6543 ", 6543, false},
+		{"", 0, true},
+		{"abc", 0, true},
+		{"0", 0, true},
+		{"65536", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParsePort(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePort(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParsePort(%q) = %d, want %d", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactDSN(t *testing.T) {
+	tests := []struct {
+		dsn  string
+		want string
+	}{
+		{"postgres://app:secret@db/jobs", "postgres://app:xxxxx@db/jobs"},
+		{"postgres://app@db/jobs", "postgres://app@db/jobs"},
+		{"host=db password=secret dbname=jobs", "host=db password=xxxxx dbname=jobs"},
+		{"host=db password='it\\'s secret' dbname=jobs", "host=db password=xxxxx dbname=jobs"},
+	}
+	for _, tt := range tests {
+		if got := RedactDSN(tt.dsn); got != tt.want {
+			t.Errorf("RedactDSN(%q) = %q, want %q", tt.dsn, got, tt.want)
+		}
+	}
+}
+
+func TestGetPasswordFromURL(t *testing.T) {
+	t.Setenv("DB_PASS", "")
+	t.Setenv(DatabaseURLEnv, "postgres://app:secret@db/jobs")
+	password, err := GetPassword(&ConnectionConfig{})
+	if err != nil || password != "" {
+		t.Errorf("GetPassword() = %q, %v, want the password of the URL to be used", password, err)
+	}
+}
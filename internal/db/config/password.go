@@ -0,0 +1,327 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/term"
+)
+
+// PasswordSource is a place the Postgres password is taken from.
+type PasswordSource string
+
+const (
+	SourceNone       PasswordSource = "none"
+	SourceURL        PasswordSource = "url"
+	SourceDBPass     PasswordSource = "DB_PASS"
+	SourcePGPassword PasswordSource = "PGPASSWORD"
+	SourceCommand    PasswordSource = "password_command"
+	SourceKeyring    PasswordSource = "keyring"
+	SourcePgpassFile PasswordSource = "pgpass"
+	SourcePrompt     PasswordSource = "prompt"
+)
+
+// KeyringService is the service name passwords are stored under in the keyring.
+const KeyringService = "jobtracker"
+
+// defaultPort is the Postgres port assumed when none is configured.
+const defaultPort = "5432"
+
+// PasswordSources lists the password sources in the order they are tried.
+var PasswordSources = []PasswordSource{SourceURL, SourceDBPass, SourcePGPassword, SourceCommand, SourceKeyring, SourcePgpassFile, SourcePrompt}
+
+// promptPassword prompts for a user to enter password to Postgres.
+func promptPassword() (string, error) {
+	fmt.Print("Postgres password: ")
+	b, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	return string(b), err
+}
+
+// GetPassword retrieves Postgres password from the first available source of PasswordSources,
+// prompting the user if there is none. SQLite databases do not need a password.
+// An empty password is returned when the URL or DSN of the config carries one.
+func GetPassword(c *ConnectionConfig) (string, error) {
+	password, source, err := c.lookupPassword(true)
+	if err != nil {
+		return "", err
+	}
+	if source == SourcePrompt {
+		return promptPassword()
+	}
+	return password, nil
+}
+
+// PasswordSource reports the source GetPassword would take the password from.
+// The password command is not run.
+func (c *ConnectionConfig) PasswordSource() (PasswordSource, error) {
+	_, source, err := c.lookupPassword(false)
+	return source, err
+}
+
+// lookupPassword finds the first available password source, running the password command if run is set.
+func (c *ConnectionConfig) lookupPassword(run bool) (string, PasswordSource, error) {
+	if c.DriverName() == DriverSQLite {
+		return "", SourceNone, nil
+	}
+	if c.hasURLPassword() {
+		return "", SourceURL, nil
+	}
+	if password := os.Getenv("DB_PASS"); password != "" {
+		return password, SourceDBPass, nil
+	}
+	if password := os.Getenv("PGPASSWORD"); password != "" {
+		return password, SourcePGPassword, nil
+	}
+	if c.PasswordCommand != "" {
+		if !run {
+			return "", SourceCommand, nil
+		}
+		password, err := runPasswordCommand(c.PasswordCommand)
+		return password, SourceCommand, err
+	}
+	if c.Keyring {
+		password, err := keyring.Get(KeyringService, c.KeyringUser())
+		if err == nil {
+			return password, SourceKeyring, nil
+		}
+		if !errors.Is(err, keyring.ErrNotFound) {
+			return "", SourceKeyring, fmt.Errorf("failed to read password from keyring: %w", err)
+		}
+	}
+	password, found, err := lookupPgpass(c.passwordParams())
+	if err != nil {
+		return "", SourcePgpassFile, err
+	}
+	if found {
+		return password, SourcePgpassFile, nil
+	}
+	return "", SourcePrompt, nil
+}
+
+// runPasswordCommand runs a shell command and returns the first line of its output.
+// The command may interact with the user, e.g. to unlock a GPG key.
+func runPasswordCommand(command string) (string, error) {
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+	cmd := exec.Command(shell, flag, command)
+	cmd.Stdin = os.Stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("password command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	password, _, _ := strings.Cut(string(out), "\n")
+	password = strings.TrimSuffix(password, "\r")
+	if password == "" {
+		return "", fmt.Errorf("password command printed no password")
+	}
+	return password, nil
+}
+
+// KeyringUser returns the name the password of the profile is stored under in the keyring.
+func (c *ConnectionConfig) KeyringUser() string {
+	if c.Profile == "" {
+		return DefaultProfile
+	}
+	return c.Profile
+}
+
+// SetKeyringPassword stores the password of the profile in the keyring.
+func (c *ConnectionConfig) SetKeyringPassword(password string) error {
+	return keyring.Set(KeyringService, c.KeyringUser(), password)
+}
+
+// DeleteKeyringPassword removes the password of the profile from the keyring,
+// reporting whether it was stored.
+func (c *ConnectionConfig) DeleteKeyringPassword() (bool, error) {
+	err := keyring.Delete(KeyringService, c.KeyringUser())
+	if errors.Is(err, keyring.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Endpoint returns the host and port the connection goes to, completed with the libpq defaults.
+// A host starting with a slash is the directory of a Unix-domain socket.
+func (c *ConnectionConfig) Endpoint() (host, port string) {
+	params := c.passwordParams()
+	return params[0], params[1]
+}
+
+// passwordParams returns the host, port, database and user of the connection
+// as matched against the entries of a password file.
+func (c *ConnectionConfig) passwordParams() [4]string {
+	params := map[string]string{
+		"host":   c.DBHost,
+		"port":   fmt.Sprint(c.DBPort),
+		"dbname": c.DBName,
+		"user":   c.DBUser,
+	}
+	if dsn := c.DatabaseURL(); dsn != "" {
+		params = parseDSN(dsn)
+	}
+	if params["host"] == "" {
+		params["host"] = "localhost"
+	}
+	if params["port"] == "" || params["port"] == "0" {
+		params["port"] = defaultPort
+	}
+	if params["user"] == "" {
+		params["user"] = os.Getenv("USER")
+	}
+	if params["dbname"] == "" {
+		params["dbname"] = params["user"]
+	}
+	return [4]string{params["host"], params["port"], params["dbname"], params["user"]}
+}
+
+// parseDSN extracts the connection parameters of a Postgres URL or key=value DSN.
+func parseDSN(dsn string) map[string]string {
+	params := map[string]string{}
+	if strings.Contains(dsn, "://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return params
+		}
+		if host, port, err := net.SplitHostPort(u.Host); err == nil {
+			params["host"], params["port"] = host, port
+		} else {
+			params["host"] = u.Host
+		}
+		params["dbname"] = strings.TrimPrefix(u.Path, "/")
+		if u.User != nil {
+			params["user"] = u.User.Username()
+		}
+		query := u.Query()
+		for key := range query {
+			params[key] = query.Get(key)
+		}
+		return params
+	}
+	// key=value pairs, with values optionally quoted and escaped with backslashes
+	rest := strings.TrimSpace(dsn)
+	for rest != "" {
+		key, after, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key, after = strings.TrimSpace(key), strings.TrimLeft(after, " ")
+		var value strings.Builder
+		quoted := strings.HasPrefix(after, "'")
+		if quoted {
+			after = after[1:]
+		}
+		i := 0
+		for ; i < len(after); i++ {
+			ch := after[i]
+			if ch == '\\' && i+1 < len(after) {
+				i++
+				value.WriteByte(after[i])
+				continue
+			}
+			if (quoted && ch == '\'') || (!quoted && ch == ' ') {
+				break
+			}
+			value.WriteByte(ch)
+		}
+		params[key] = value.String()
+		rest = strings.TrimSpace(after[min(i+1, len(after)):])
+	}
+	return params
+}
+
+// PgpassPath returns the location of the password file, from PGPASSFILE or the libpq default.
+func PgpassPath() (string, error) {
+	if path := os.Getenv("PGPASSFILE"); path != "" {
+		return path, nil
+	}
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "postgresql", "pgpass.conf"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".pgpass"), nil
+}
+
+// lookupPgpass finds the password of a connection (host, port, database, user) in the password file.
+// Like libpq, files accessible by group or others are ignored.
+func lookupPgpass(params [4]string) (string, bool, error) {
+	path, err := PgpassPath()
+	if err != nil {
+		return "", false, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false, nil
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0 {
+		fmt.Fprintf(os.Stderr, "Warning: password file %s is ignored, it must not be accessible by group or others (chmod 600).\n", path)
+		return "", false, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := splitPgpassLine(line)
+		if len(fields) != 5 {
+			continue
+		}
+		matches := true
+		for i, param := range params {
+			if fields[i] != "*" && fields[i] != param {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return fields[4], true, nil
+		}
+	}
+	return "", false, scanner.Err()
+}
+
+// splitPgpassLine splits a line of the password file on colons, honoring \: and \\ escapes.
+func splitPgpassLine(line string) []string {
+	var fields []string
+	var field strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case line[i] == ':' && len(fields) < 4:
+			fields = append(fields, field.String())
+			field.Reset()
+		default:
+			field.WriteByte(line[i])
+		}
+	}
+	return append(fields, field.String())
+}
@@ -7,42 +7,167 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"modernc.org/sqlite"
 )
 
-// Connect connects to the Postgres database using config and password.
+// Dialect identifies the SQL database backing a connection.
+type Dialect string
+
+const (
+	Postgres Dialect = config.DriverPostgres
+	SQLite   Dialect = config.DriverSQLite
+)
+
+// Connect connects to the database selected in config, using password for Postgres.
 func Connect(ctx context.Context, cfg *config.ConnectionConfig, password string) (*sql.DB, error) {
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable connect_timeout=15",
-		cfg.DBHost,
-		strconv.Itoa(cfg.DBPort),
-		cfg.DBUser,
-		password,
-		cfg.DBName,
-	)
-	var err error
+	switch cfg.DriverName() {
+	case config.DriverPostgres:
+		return connectPostgres(ctx, cfg, password)
+	case config.DriverSQLite:
+		return connectSQLite(ctx, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported database driver: %q (allowed: %s, %s)", cfg.Driver, config.DriverPostgres, config.DriverSQLite)
+	}
+}
+
+// connectPostgres connects to the Postgres database using config and password.
+func connectPostgres(ctx context.Context, cfg *config.ConnectionConfig, password string) (*sql.DB, error) {
+	connStr, err := PostgresDSN(cfg, password)
+	if err != nil {
+		return nil, err
+	}
+	connectTimeout, _, err := cfg.Timeouts()
+	if err != nil {
+		return nil, err
+	}
 	db, err := sql.Open("postgres", connStr)
 	if err != nil {
 		return nil, err
 	}
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
 	defer cancel()
 	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, err
 	}
 	return db, nil
 }
 
+// PostgresDSN builds the key=value connection string of a Postgres database from config and password.
+// A URL or DSN from config is used as is, with the settings configured explicitly taking precedence,
+// while the fields of config are completed with defaults for the SSL mode and the connect timeout.
+func PostgresDSN(cfg *config.ConnectionConfig, password string) (string, error) {
+	connectTimeout, statementTimeout, err := cfg.Timeouts()
+	if err != nil {
+		return "", err
+	}
+	var pairs []string
+	add := func(key, value string) {
+		if value != "" {
+			pairs = append(pairs, key+"="+quoteDSNValue(value))
+		}
+	}
+
+	dsn := cfg.DatabaseURL()
+	switch {
+	case strings.Contains(dsn, "://"):
+		base, err := pq.ParseURL(dsn)
+		if err != nil {
+			return "", err
+		}
+		pairs = append(pairs, base)
+	case dsn != "":
+		pairs = append(pairs, dsn)
+	default:
+		add("host", cfg.DBHost)
+		add("port", strconv.Itoa(cfg.DBPort))
+		add("user", cfg.DBUser)
+		add("dbname", cfg.DBName)
+		if cfg.SSLMode == "" {
+			add("sslmode", config.DefaultSSLMode)
+		}
+	}
+	// Later pairs override the ones before
+	add("password", password)
+	add("sslmode", cfg.SSLMode)
+	add("sslrootcert", cfg.SSLRootCert)
+	add("sslcert", cfg.SSLCert)
+	add("sslkey", cfg.SSLKey)
+	if cfg.ConnectTimeout != "" || dsn == "" {
+		add("connect_timeout", strconv.Itoa(max(1, int(connectTimeout.Round(time.Second)/time.Second))))
+	}
+	if statementTimeout > 0 {
+		// Passed on to the server as a run-time parameter, in milliseconds
+		add("statement_timeout", strconv.FormatInt(statementTimeout.Milliseconds(), 10))
+	}
+	applicationName := cfg.ApplicationName
+	if applicationName == "" && dsn == "" {
+		applicationName = config.DefaultApplicationName
+	}
+	add("application_name", applicationName)
+	return strings.Join(pairs, " "), nil
+}
+
+// quoteDSNValue quotes a value of a key=value connection string if needed.
+func quoteDSNValue(value string) string {
+	if !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// connectSQLite opens the SQLite database file from config, creating it if needed.
+func connectSQLite(ctx context.Context, cfg *config.ConnectionConfig) (*sql.DB, error) {
+	path := cfg.DBPath
+	if path == "" {
+		var err error
+		if path, err = config.DefaultSQLitePath(); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	connStr := "file:" + path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite"
+	db, err := sql.Open("sqlite", connStr)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer, so transactions are serialized on one connection
+	db.SetMaxOpenConns(1)
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// DialectOf reports the SQL dialect of an open database.
+func DialectOf(db *sql.DB) Dialect {
+	if _, ok := db.Driver().(*sqlite.Driver); ok {
+		return SQLite
+	}
+	return Postgres
+}
+
 // CheckTableExists checks if a table exists in the database.
 func CheckTableExists(ctx context.Context, db *sql.DB, tableName string) (bool, error) {
 	var exists bool
 	query := `SELECT EXISTS (
-		       SELECT 1 FROM information_schema.tables 
+		       SELECT 1 FROM information_schema.tables
 		       WHERE table_schema = 'public' AND table_name = $1
 	       )`
+	if DialectOf(db) == SQLite {
+		query = `SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = $1)`
+	}
 	if err := db.QueryRowContext(ctx, query, tableName).Scan(&exists); err != nil {
 		return false, err
 	}
@@ -0,0 +1,78 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"testing"
+
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+)
+
+func TestPostgresDSN(t *testing.T) {
+	t.Setenv(config.DatabaseURLEnv, "")
+	tests := []struct {
+		name     string
+		cfg      config.ConnectionConfig
+		password string
+		want     string
+	}{
+		{
+			name:     "fields with defaults",
+			cfg:      config.ConnectionConfig{DBHost: "localhost", DBPort: 5432, DBUser: "postgres", DBName: "jobs"},
+			password: "secret",
+			want:     "host=localhost port=5432 user=postgres dbname=jobs sslmode=disable password=secret connect_timeout=15 application_name=jobtracker",
+		},
+		{
+			name: "fields with TLS and timeouts",
+			cfg: config.ConnectionConfig{
+				DBHost: "db.example.com", DBPort: 6432, DBUser: "app", DBName: "jobs",
+				SSLMode: "verify-full", SSLRootCert: "/certs/root ca.crt",
+				ApplicationName: "laptop", ConnectTimeout: "1m", StatementTimeout: "2.5s",
+			},
+			want: "host=db.example.com port=6432 user=app dbname=jobs sslmode=verify-full " +
+				"sslrootcert='/certs/root ca.crt' connect_timeout=60 statement_timeout=2500 application_name=laptop",
+		},
+		{
+			name:     "URL keeps its settings",
+			cfg:      config.ConnectionConfig{URL: "postgres://app@db.example.com:5432/jobs?sslmode=require"},
+			password: "it's",
+			want:     "dbname='jobs' host='db.example.com' port='5432' sslmode='require' user='app' password='it\\'s'",
+		},
+		{
+			name: "DSN with overrides",
+			cfg:  config.ConnectionConfig{URL: "host=db dbname=jobs", SSLMode: "verify-ca", SSLCert: "c.crt", SSLKey: "c.key"},
+			want: "host=db dbname=jobs sslmode=verify-ca sslcert=c.crt sslkey=c.key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PostgresDSN(&tt.cfg, tt.password)
+			if err != nil {
+				t.Fatalf("PostgresDSN() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("PostgresDSN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := PostgresDSN(&config.ConnectionConfig{URL: "mysql://db/jobs"}, ""); err == nil {
+		t.Error("PostgresDSN() with non-Postgres URL should return error")
+	}
+	if _, err := PostgresDSN(&config.ConnectionConfig{StatementTimeout: "soon"}, ""); err == nil {
+		t.Error("PostgresDSN() with invalid timeout should return error")
+	}
+}
+
+func TestPostgresDSNFromEnvironment(t *testing.T) {
+	t.Setenv(config.DatabaseURLEnv, "postgres://ci@localhost/jobs")
+	cfg := config.ConnectionConfig{DBHost: "ignored", DBPort: 5432}
+	got, err := PostgresDSN(&cfg, "")
+	if err != nil {
+		t.Fatalf("PostgresDSN() error = %v", err)
+	}
+	if want := "dbname='jobs' host='localhost' user='ci'"; got != want {
+		t.Errorf("PostgresDSN() = %q, want %q", got, want)
+	}
+}
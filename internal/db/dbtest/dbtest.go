@@ -0,0 +1,54 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package dbtest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"github.com/spolivin/jobtracker/v2/internal/db/migrate"
+)
+
+// NewSQLiteStore opens a migrated SQLite database in a temporary directory, closed when the test ends,
+// and adds the seed applications in order (in the Applied status if none is set).
+func NewSQLiteStore(t testing.TB, seed ...db.JobApplication) db.Store {
+	t.Helper()
+	ctx := context.Background()
+	cfg := &config.ConnectionConfig{
+		Driver: config.DriverSQLite,
+		DBPath: filepath.Join(t.TempDir(), "jobtracker.db"),
+	}
+	dbase, err := db.Connect(ctx, cfg, "")
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { dbase.Close() })
+	if err := migrate.Run(ctx, dbase); err != nil {
+		t.Fatalf("migrate.Run() error = %v", err)
+	}
+	store := db.NewStore(dbase)
+	for _, app := range seed {
+		status := app.Status
+		if status == "" {
+			status = "Applied"
+		}
+		if _, err := store.Add(ctx, app.Company, app.Position, status, app.Tags, false); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	return store
+}
+
+// Applications returns the applications to Google, Apple and Stripe (IDs 1 to 3 once added),
+// carrying the given tags.
+func Applications(tags ...string) []db.JobApplication {
+	return []db.JobApplication{
+		{Company: "Google", Position: "Engineer", Tags: tags},
+		{Company: "Apple", Position: "Data Scientist", Tags: tags},
+		{Company: "Stripe", Position: "Backend Engineer", Tags: tags},
+	}
+}
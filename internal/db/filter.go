@@ -0,0 +1,212 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Filter narrows down the job applications returned by Read.
+// The zero value matches all applications.
+type Filter struct {
+	// Statuses restricts the result to applications in any of the statuses (case-insensitive).
+	Statuses []string
+	// Company matches applications whose company name contains the text (case-insensitive).
+	Company string
+	// Since matches applications created at or after the time.
+	Since time.Time
+	// Until matches applications created before the time.
+	Until time.Time
+	// UpdatedSince matches applications updated at or after the time.
+	UpdatedSince time.Time
+	// Stale matches applications not updated for at least the duration.
+	Stale time.Duration
+	// Tags restricts the result to applications carrying the tags.
+	Tags []string
+	// AnyTag matches applications carrying any of the tags instead of all of them.
+	AnyTag bool
+}
+
+// IsZero reports whether the filter matches all applications.
+func (f Filter) IsZero() bool {
+	return len(f.Statuses) == 0 && f.Company == "" && f.Since.IsZero() && f.Until.IsZero() &&
+		f.UpdatedSince.IsZero() && f.Stale == 0 && len(f.Tags) == 0
+}
+
+// validOperators defines the allowed comparison operators for filter conditions
+var validOperators = map[string]bool{
+	"=":    true,
+	"<":    true,
+	">=":   true,
+	"LIKE": true,
+}
+
+// queryBuilder accumulates SQL conditions and their positional arguments.
+type queryBuilder struct {
+	dialect    Dialect
+	conditions []string
+	args       []any
+}
+
+// arg registers a query argument and returns its placeholder.
+func (b *queryBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// compare adds a condition comparing a whitelisted column with a value.
+func (b *queryBuilder) compare(column, operator string, value any) error {
+	if err := ValidateColumnName(column); err != nil {
+		return err
+	}
+	if !validOperators[operator] {
+		return fmt.Errorf("invalid operator: %q", operator)
+	}
+	left, right := b.column(normalizeColumn(column)), b.value(value)
+	if _, ok := value.(string); ok {
+		left = "LOWER(" + left + ")"
+		if operator == "LIKE" {
+			right += ` ESCAPE '\'`
+		}
+	}
+	b.conditions = append(b.conditions, left+" "+operator+" "+right)
+	return nil
+}
+
+// column returns the expression of a whitelisted column to compare and sort by.
+func (b *queryBuilder) column(name string) string {
+	// SQLite keeps timestamps as text with varying offsets, so they are normalized
+	if b.dialect == SQLite && timeColumns[name] {
+		return "datetime(" + name + ")"
+	}
+	return name
+}
+
+// value registers a query argument to compare with a column and returns its expression.
+func (b *queryBuilder) value(v any) string {
+	t, ok := v.(time.Time)
+	if !ok {
+		return b.arg(v)
+	}
+	placeholder := b.arg(t.UTC())
+	if b.dialect == SQLite {
+		return "datetime(" + placeholder + ")"
+	}
+	return placeholder
+}
+
+// where returns the WHERE clause for the accumulated conditions.
+func (b *queryBuilder) where() string {
+	if len(b.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(b.conditions, " AND ")
+}
+
+// build compiles the filter into conditions of a parameterized query.
+func (f Filter) build(b *queryBuilder) error {
+	if len(f.Statuses) > 0 {
+		alternatives := queryBuilder{dialect: b.dialect, args: b.args}
+		for _, status := range f.Statuses {
+			if err := alternatives.compare("status", "=", strings.ToLower(strings.TrimSpace(status))); err != nil {
+				return err
+			}
+		}
+		b.args = alternatives.args
+		b.conditions = append(b.conditions, "("+strings.Join(alternatives.conditions, " OR ")+")")
+	}
+	if f.Company != "" {
+		pattern := "%" + escapeLike(strings.ToLower(f.Company)) + "%"
+		if err := b.compare("company", "LIKE", pattern); err != nil {
+			return err
+		}
+	}
+	if !f.Since.IsZero() {
+		if err := b.compare("created_at", ">=", f.Since); err != nil {
+			return err
+		}
+	}
+	if !f.Until.IsZero() {
+		if err := b.compare("created_at", "<", f.Until); err != nil {
+			return err
+		}
+	}
+	if !f.UpdatedSince.IsZero() {
+		if err := b.compare("updated_at", ">=", f.UpdatedSince); err != nil {
+			return err
+		}
+	}
+	if f.Stale < 0 {
+		return fmt.Errorf("stale duration cannot be negative")
+	}
+	if f.Stale > 0 {
+		if err := b.compare("updated_at", "<", time.Now().Add(-f.Stale)); err != nil {
+			return err
+		}
+	}
+	if len(f.Tags) > 0 {
+		tags, err := NormalizeTags(f.Tags)
+		if err != nil {
+			return err
+		}
+		placeholders := make([]string, len(tags))
+		for i, tag := range tags {
+			placeholders[i] = b.arg(tag)
+		}
+		condition := `id IN (SELECT application_tags.application_id FROM application_tags
+			JOIN tags ON tags.id = application_tags.tag_id
+			WHERE tags.name IN (` + strings.Join(placeholders, ", ") + `)`
+		if !f.AnyTag {
+			condition += ` GROUP BY application_tags.application_id HAVING COUNT(DISTINCT tags.id) = ` + strconv.Itoa(len(tags))
+		}
+		b.conditions = append(b.conditions, condition+`)`)
+	}
+	return nil
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// ParseDuration parses a duration such as "14d", "2w" or any value accepted by time.ParseDuration.
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	units := map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour}
+	for suffix, unit := range units {
+		if n, ok := strings.CutSuffix(value, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil || count < 0 {
+				return 0, fmt.Errorf("invalid duration: %q (examples: 14d, 2w, 36h)", value)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration: %q (examples: 14d, 2w, 36h)", value)
+	}
+	return d, nil
+}
+
+// ParseDate parses a date (2006-01-02, in local time) or an RFC 3339 timestamp.
+// With endOfDay set, a date is resolved to the start of the following day,
+// so that it can be used as an exclusive upper bound covering the whole day.
+func ParseDate(value string, endOfDay bool) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %q (expected YYYY-MM-DD or RFC 3339)", value)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
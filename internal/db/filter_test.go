@@ -0,0 +1,182 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFilterBuild tests that filters compile to parameterized conditions
+func TestFilterBuild(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		filter   Filter
+		dialect  Dialect
+		wantArgs []any
+		contains []string
+	}{
+		{"no filter", Filter{}, Postgres, nil, nil},
+		{
+			name:     "statuses",
+			filter:   Filter{Statuses: []string{"Applied", " interview "}},
+			wantArgs: []any{"applied", "interview"},
+			contains: []string{"(LOWER(status) = $1 OR LOWER(status) = $2)"},
+		},
+		{
+			name:     "company with wildcards",
+			filter:   Filter{Company: "Meta_50%"},
+			wantArgs: []any{`%meta\_50\%%`},
+			contains: []string{`LOWER(company) LIKE $1 ESCAPE '\'`},
+		},
+		{
+			name:     "dates on Postgres",
+			filter:   Filter{Since: since, Until: since.AddDate(0, 1, 0)},
+			dialect:  Postgres,
+			wantArgs: []any{since, since.AddDate(0, 1, 0)},
+			contains: []string{"created_at >= $1", "created_at < $2"},
+		},
+		{
+			name:     "dates on SQLite",
+			filter:   Filter{UpdatedSince: since},
+			dialect:  SQLite,
+			wantArgs: []any{since},
+			contains: []string{"datetime(updated_at) >= datetime($1)"},
+		},
+		{
+			name:     "combined with tags",
+			filter:   Filter{Statuses: []string{"Offer"}, Tags: []string{"Remote", "backend"}},
+			wantArgs: []any{"offer", "remote", "backend"},
+			contains: []string{"LOWER(status) = $1", "IN ($2, $3)", "HAVING COUNT(DISTINCT tags.id) = 2"},
+		},
+		{
+			name:     "any tag",
+			filter:   Filter{Tags: []string{"remote", "backend"}, AnyTag: true},
+			wantArgs: []any{"remote", "backend"},
+			contains: []string{"IN ($1, $2))"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := queryBuilder{dialect: tt.dialect}
+			if err := tt.filter.build(&b); err != nil {
+				t.Fatalf("build() error = %v", err)
+			}
+			if !reflect.DeepEqual(b.args, tt.wantArgs) {
+				t.Errorf("build() args = %v, want %v", b.args, tt.wantArgs)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(b.where(), want) {
+					t.Errorf("build() where = %q, want it to contain %q", b.where(), want)
+				}
+			}
+			if tt.filter.AnyTag && strings.Contains(b.where(), "HAVING") {
+				t.Errorf("build() where = %q, want no HAVING clause for any-tag matching", b.where())
+			}
+			if tt.filter.IsZero() != (len(b.conditions) == 0) {
+				t.Errorf("IsZero() = %v with %d conditions", tt.filter.IsZero(), len(b.conditions))
+			}
+		})
+	}
+
+	invalid := []Filter{
+		{Tags: []string{"bad tag"}},
+		{Stale: -time.Hour},
+	}
+	for _, filter := range invalid {
+		var b queryBuilder
+		if err := filter.build(&b); err == nil {
+			t.Errorf("build() with %+v should return error", filter)
+		}
+	}
+}
+
+// TestQueryBuilderCompare tests that only whitelisted columns and operators are accepted
+func TestQueryBuilderCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		column   string
+		operator string
+		wantErr  bool
+	}{
+		{"valid", "status", "=", false},
+		{"injected column", "status; DROP TABLE applications--", "=", true},
+		{"unknown column", "salary", "=", true},
+		{"injected operator", "status", "= 'x' OR 1=1 OR status =", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var b queryBuilder
+			err := b.compare(tt.column, tt.operator, "applied")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("compare(%q, %q) error = %v, wantErr %v", tt.column, tt.operator, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestParseDuration tests day and week suffixes on top of Go durations
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"14d", 14 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"0d", 0, false},
+		{"d", 0, true},
+		{"-3d", 0, true},
+		{"1.5d", 0, true},
+		{"soon", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestParseDate tests dates and timestamps, including the end-of-day bound
+func TestParseDate(t *testing.T) {
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.Local)
+	stamp := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		input    string
+		endOfDay bool
+		want     time.Time
+		wantErr  bool
+	}{
+		{"date", "2026-03-14", false, day, false},
+		{"date as upper bound", "2026-03-14", true, day.AddDate(0, 0, 1), false},
+		{"timestamp", "2026-03-14T09:30:00Z", true, stamp, false},
+		{"invalid", "14/03/2026", false, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDate(tt.input, tt.endOfDay)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDate(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseDate(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
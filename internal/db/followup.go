@@ -0,0 +1,217 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+)
+
+// DefaultFollowUpRules defines the follow-up rules used when none are configured.
+var DefaultFollowUpRules = []config.FollowUpRuleConfig{
+	{Status: "Applied", After: "7d"},
+	{Status: "Screening", After: "7d"},
+	{Status: "Interview", After: "5d"},
+}
+
+// Sources of follow-up dates.
+const (
+	// FollowUpScheduled marks follow-ups whose date was set explicitly (or snoozed to).
+	FollowUpScheduled = "scheduled"
+	// FollowUpByRule marks follow-ups whose date was derived from a rule.
+	FollowUpByRule = "rule"
+)
+
+// FollowUpRule schedules a follow-up for applications left in a status without changes for a duration.
+type FollowUpRule struct {
+	Status string
+	After  time.Duration
+}
+
+// String describes the rule, e.g. "7d after Applied".
+func (r FollowUpRule) String() string {
+	after := r.After.String()
+	if r.After%(24*time.Hour) == 0 {
+		after = fmt.Sprintf("%dd", r.After/(24*time.Hour))
+	}
+	return after + " after " + r.Status
+}
+
+// NewFollowUpRules builds follow-up rules from config, falling back to the default ones if config is nil.
+// Configured statuses must be part of the workflow, while default rules for statuses
+// missing from a custom workflow are dropped.
+func NewFollowUpRules(cfg *config.FollowUpConfig, workflow *Workflow) ([]FollowUpRule, error) {
+	configured := cfg != nil
+	entries := DefaultFollowUpRules
+	if configured {
+		entries = cfg.Rules
+	}
+	seen := make(map[string]bool, len(entries))
+	rules := make([]FollowUpRule, 0, len(entries))
+	for _, entry := range entries {
+		status, err := workflow.NormalizeStatus(entry.Status)
+		if err != nil {
+			if !configured {
+				continue
+			}
+			return nil, fmt.Errorf("follow-up rule: %w", err)
+		}
+		if seen[status] {
+			return nil, fmt.Errorf("duplicate follow-up rule status: %q", status)
+		}
+		seen[status] = true
+		after, err := ParseDuration(entry.After)
+		if err != nil {
+			return nil, fmt.Errorf("follow-up rule for %q: %w", status, err)
+		}
+		if after <= 0 {
+			return nil, fmt.Errorf("follow-up rule for %q: duration must be positive", status)
+		}
+		rules = append(rules, FollowUpRule{Status: status, After: after})
+	}
+	return rules, nil
+}
+
+// FollowUpState is the stored follow-up state of a job application.
+type FollowUpState struct {
+	ApplicationID int
+	// DueAt is the date of the pending follow-up set explicitly, zero if there is none.
+	DueAt time.Time
+	// LastDone is when a follow-up was last marked done, zero if never.
+	LastDone time.Time
+}
+
+// FollowUp is a follow-up reminder of a job application.
+type FollowUp struct {
+	JobApplication
+	DueAt time.Time `json:"due_at"`
+	// Source is FollowUpScheduled or FollowUpByRule.
+	Source string `json:"source"`
+	// Rule describes the rule the date was derived from.
+	Rule string `json:"rule,omitempty"`
+}
+
+// ScheduleFollowUps determines the next follow-up of every application, ordered by due date.
+// A date set explicitly takes precedence, unless the application reached a terminal status of the workflow;
+// otherwise the rule of the status of the application applies, counting from its last change
+// or the last follow-up marked done, whichever is later.
+// Applications in statuses without a rule and without a date set are left out.
+func ScheduleFollowUps(apps []JobApplication, states []FollowUpState, rules []FollowUpRule, workflow *Workflow) []FollowUp {
+	byApp := make(map[int]FollowUpState, len(states))
+	for _, state := range states {
+		byApp[state.ApplicationID] = state
+	}
+	byStatus := make(map[string]FollowUpRule, len(rules))
+	for _, rule := range rules {
+		byStatus[strings.ToLower(rule.Status)] = rule
+	}
+
+	var followUps []FollowUp
+	for _, app := range apps {
+		state := byApp[app.ID]
+		if !state.DueAt.IsZero() && !workflow.IsTerminal(app.Status) {
+			followUps = append(followUps, FollowUp{JobApplication: app, DueAt: state.DueAt, Source: FollowUpScheduled})
+			continue
+		}
+		rule, ok := byStatus[strings.ToLower(app.Status)]
+		if !ok {
+			continue
+		}
+		since := app.UpdatedAt
+		if state.LastDone.After(since) {
+			since = state.LastDone
+		}
+		followUps = append(followUps, FollowUp{JobApplication: app, DueAt: since.Add(rule.After), Source: FollowUpByRule, Rule: rule.String()})
+	}
+	sort.SliceStable(followUps, func(i, j int) bool {
+		return followUps[i].DueAt.Before(followUps[j].DueAt)
+	})
+	return followUps
+}
+
+// ScheduleFollowUp sets the date of the next follow-up of a job application, replacing a pending one.
+// Returns ErrNotFound if the application does not exist.
+func (s *JobApplicationsStore) ScheduleFollowUp(ctx context.Context, applicationID int, dueAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkApplicationExists(ctx, tx, applicationID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM follow_ups WHERE application_id=$1 AND done_at IS NULL`, applicationID); err != nil {
+		return err
+	}
+	query := `INSERT INTO follow_ups (application_id, due_at) VALUES ($1, $2)`
+	if _, err := tx.ExecContext(ctx, query, applicationID, dueAt.UTC().Truncate(time.Second)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// CompleteFollowUp marks the pending follow-up of a job application as done, restarting the rule
+// of its status from now. Returns ErrNotFound if the application does not exist.
+func (s *JobApplicationsStore) CompleteFollowUp(ctx context.Context, applicationID int) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkApplicationExists(ctx, tx, applicationID); err != nil {
+		return err
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	res, err := tx.ExecContext(ctx, `UPDATE follow_ups SET done_at=$2 WHERE application_id=$1 AND done_at IS NULL`, applicationID, now)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		// Follow-ups derived from rules have no record yet
+		query := `INSERT INTO follow_ups (application_id, due_at, done_at) VALUES ($1, $2, $2)`
+		if _, err := tx.ExecContext(ctx, query, applicationID, now); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ReadFollowUps retrieves the follow-up state of the job applications having any follow-up records.
+func (s *JobApplicationsStore) ReadFollowUps(ctx context.Context) ([]FollowUpState, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT application_id, due_at, done_at FROM follow_ups ORDER BY application_id, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var states []FollowUpState
+	for rows.Next() {
+		var id int
+		var dueAt time.Time
+		var doneAt sql.NullTime
+		if err := rows.Scan(&id, &dueAt, &doneAt); err != nil {
+			return nil, err
+		}
+		if len(states) == 0 || states[len(states)-1].ApplicationID != id {
+			states = append(states, FollowUpState{ApplicationID: id})
+		}
+		state := &states[len(states)-1]
+		if !doneAt.Valid {
+			state.DueAt = dueAt
+		} else if doneAt.Time.After(state.LastDone) {
+			state.LastDone = doneAt.Time
+		}
+	}
+	return states, rows.Err()
+}
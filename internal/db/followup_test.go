@@ -0,0 +1,157 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+)
+
+func TestNewFollowUpRules(t *testing.T) {
+	custom, err := NewWorkflow(&config.WorkflowConfig{Statuses: []string{"Applied", "Hired"}})
+	if err != nil {
+		t.Fatalf("NewWorkflow() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		cfg      *config.FollowUpConfig
+		workflow *Workflow
+		want     []FollowUpRule
+		wantErr  bool
+	}{
+		{
+			name:     "nil config uses default",
+			workflow: DefaultWorkflow(),
+			want: []FollowUpRule{
+				{Status: "Applied", After: 7 * 24 * time.Hour},
+				{Status: "Screening", After: 7 * 24 * time.Hour},
+				{Status: "Interview", After: 5 * 24 * time.Hour},
+			},
+		},
+		{
+			name:     "default rules skip statuses missing from workflow",
+			workflow: custom,
+			want:     []FollowUpRule{{Status: "Applied", After: 7 * 24 * time.Hour}},
+		},
+		{
+			name:     "configured rules",
+			cfg:      &config.FollowUpConfig{Rules: []config.FollowUpRuleConfig{{Status: "offer", After: "2w"}}},
+			workflow: DefaultWorkflow(),
+			want:     []FollowUpRule{{Status: "Offer", After: 14 * 24 * time.Hour}},
+		},
+		{
+			name:     "empty rules disable follow-ups",
+			cfg:      &config.FollowUpConfig{},
+			workflow: DefaultWorkflow(),
+			want:     []FollowUpRule{},
+		},
+		{
+			name:     "unknown status",
+			cfg:      &config.FollowUpConfig{Rules: []config.FollowUpRuleConfig{{Status: "Hired", After: "7d"}}},
+			workflow: DefaultWorkflow(),
+			wantErr:  true,
+		},
+		{
+			name: "duplicate status",
+			cfg: &config.FollowUpConfig{Rules: []config.FollowUpRuleConfig{
+				{Status: "Applied", After: "7d"},
+				{Status: "applied", After: "3d"},
+			}},
+			workflow: DefaultWorkflow(),
+			wantErr:  true,
+		},
+		{
+			name:     "invalid duration",
+			cfg:      &config.FollowUpConfig{Rules: []config.FollowUpRuleConfig{{Status: "Applied", After: "soon"}}},
+			workflow: DefaultWorkflow(),
+			wantErr:  true,
+		},
+		{
+			name:     "zero duration",
+			cfg:      &config.FollowUpConfig{Rules: []config.FollowUpRuleConfig{{Status: "Applied", After: "0d"}}},
+			workflow: DefaultWorkflow(),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewFollowUpRules(tt.cfg, tt.workflow)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewFollowUpRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewFollowUpRules() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollowUpRuleString(t *testing.T) {
+	tests := []struct {
+		rule FollowUpRule
+		want string
+	}{
+		{FollowUpRule{Status: "Applied", After: 7 * 24 * time.Hour}, "7d after Applied"},
+		{FollowUpRule{Status: "Interview", After: 36 * time.Hour}, "36h0m0s after Interview"},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestScheduleFollowUps(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	apps := []JobApplication{
+		{ID: 1, Company: "Google", Status: "Applied", UpdatedAt: base},
+		{ID: 2, Company: "Apple", Status: "Interview", UpdatedAt: base},
+		{ID: 3, Company: "Tesla", Status: "Rejected", UpdatedAt: base},
+		{ID: 4, Company: "Meta", Status: "Offer", UpdatedAt: base},
+		{ID: 5, Company: "Amazon", Status: "Applied", UpdatedAt: base},
+		{ID: 6, Company: "Stripe", Status: "Withdrawn", UpdatedAt: base},
+	}
+	states := []FollowUpState{
+		// A scheduled date wins over the rule
+		{ApplicationID: 1, DueAt: base.Add(20 * day)},
+		// A scheduled date applies to statuses without rules as well
+		{ApplicationID: 4, DueAt: base.Add(day)},
+		// A follow-up done later than the last change restarts the rule
+		{ApplicationID: 5, LastDone: base.Add(3 * day)},
+		// A scheduled date is dropped once the application is closed
+		{ApplicationID: 6, DueAt: base.Add(2 * day)},
+	}
+	rules := []FollowUpRule{
+		{Status: "Applied", After: 7 * day},
+		{Status: "Interview", After: 5 * day},
+	}
+
+	got := ScheduleFollowUps(apps, states, rules, DefaultWorkflow())
+	want := []struct {
+		id     int
+		due    time.Time
+		source string
+		rule   string
+	}{
+		{4, base.Add(day), FollowUpScheduled, ""},
+		{2, base.Add(5 * day), FollowUpByRule, "5d after Interview"},
+		{5, base.Add(10 * day), FollowUpByRule, "7d after Applied"},
+		{1, base.Add(20 * day), FollowUpScheduled, ""},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ScheduleFollowUps() = %+v, want %d follow-ups", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].ID != w.id || !got[i].DueAt.Equal(w.due) || got[i].Source != w.source || got[i].Rule != w.rule {
+			t.Errorf("ScheduleFollowUps()[%d] = #%d %v %s %q, want #%d %v %s %q",
+				i, got[i].ID, got[i].DueAt, got[i].Source, got[i].Rule, w.id, w.due, w.source, w.rule)
+		}
+	}
+}
@@ -6,42 +6,219 @@ package db
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"strconv"
+	"strings"
+	"time"
 )
 
-// Wrapper around SQL-connection.
+// Postgres-backed implementation of Store.
 type JobApplicationsStore struct {
-	db *sql.DB
+	db       *sql.DB
+	dialect  Dialect
+	workflow *Workflow
 }
 
 // Constructor for JobApplicationsStore.
 func NewJobApplicationStore(db *sql.DB) *JobApplicationsStore {
-	return &JobApplicationsStore{db: db}
+	return &JobApplicationsStore{db: db, dialect: Postgres, workflow: DefaultWorkflow()}
 }
 
-// Add adds a new job application to the database.
-func (s *JobApplicationsStore) Add(ctx context.Context, company, position, status string) error {
-	query := `INSERT INTO applications (company, position, status) VALUES ($1, $2, $3)`
-	if _, err := s.db.ExecContext(ctx, query, company, position, status); err != nil {
-		return err
+// SetWorkflow replaces the status workflow used to validate status changes.
+func (s *JobApplicationsStore) SetWorkflow(w *Workflow) {
+	s.workflow = w
+}
+
+// Workflow returns the status workflow used by the store.
+func (s *JobApplicationsStore) Workflow() *Workflow {
+	if s.workflow == nil {
+		s.workflow = DefaultWorkflow()
 	}
-	return nil
+	return s.workflow
 }
 
-// Read retrieves all job applications from the database with possible sorting by a specified field.
-func (s *JobApplicationsStore) Read(ctx context.Context, sortBy string, descending bool) ([]JobApplication, error) {
-	query := `SELECT * FROM applications`
+// Add adds a new job application with optional tags to the database and returns its ID.
+// Status must be part of the workflow unless force is set.
+func (s *JobApplicationsStore) Add(ctx context.Context, company, position, status string, tags []string, force bool) (int, error) {
+	status = strings.TrimSpace(status)
+	if !force {
+		var err error
+		if status, err = s.Workflow().NormalizeStatus(status); err != nil {
+			return 0, err
+		}
+	}
+	tags, err := NormalizeTags(tags)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	query := `INSERT INTO applications (company, position, status) VALUES ($1, $2, $3) RETURNING id`
+	if err := tx.QueryRowContext(ctx, query, company, position, status).Scan(&id); err != nil {
+		return 0, err
+	}
+	if err := recordStatusChange(ctx, tx, id, "", status, time.Time{}); err != nil {
+		return 0, err
+	}
+	if _, err := insertTags(ctx, tx, id, tags); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// ImportOptions controls how Insert treats the identity and timestamps of a record.
+type ImportOptions struct {
+	KeepIDs        bool
+	KeepTimestamps bool
+	Force          bool
+}
+
+// Insert inserts a complete job application record, e.g. one loaded from an exported file.
+// IDs and timestamps are kept only if requested and set; otherwise the database assigns them.
+// Returns the ID of the inserted application.
+func (s *JobApplicationsStore) Insert(ctx context.Context, app JobApplication, opts ImportOptions) (int, error) {
+	status := strings.TrimSpace(app.Status)
+	if !opts.Force {
+		var err error
+		if status, err = s.Workflow().NormalizeStatus(status); err != nil {
+			return 0, err
+		}
+	}
+
+	tags, err := NormalizeTags(app.Tags)
+	if err != nil {
+		return 0, err
+	}
+
+	columns := []string{"company", "position", "status"}
+	args := []any{app.Company, app.Position, status}
+	if opts.KeepIDs && app.ID > 0 {
+		columns = append(columns, "id")
+		args = append(args, app.ID)
+	}
+	var createdAt time.Time
+	if opts.KeepTimestamps {
+		if !app.CreatedAt.IsZero() {
+			createdAt = app.CreatedAt
+			columns = append(columns, "created_at")
+			args = append(args, app.CreatedAt)
+		}
+		if !app.UpdatedAt.IsZero() {
+			columns = append(columns, "updated_at")
+			args = append(args, app.UpdatedAt)
+		}
+	}
+	placeholders := make([]string, len(columns))
+	for i := range columns {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var id int
+	query := "INSERT INTO applications (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(placeholders, ", ") + ") RETURNING id"
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&id); err != nil {
+		return 0, err
+	}
+	if err := recordStatusChange(ctx, tx, id, "", status, createdAt); err != nil {
+		return 0, err
+	}
+	if _, err := insertTags(ctx, tx, id, tags); err != nil {
+		return 0, err
+	}
+	if err := insertNotes(ctx, tx, id, app.Notes, opts.KeepTimestamps); err != nil {
+		return 0, err
+	}
+	return id, tx.Commit()
+}
+
+// SyncIDSequence moves the ID sequence past the largest ID, e.g. after inserting records with explicit IDs.
+func (s *JobApplicationsStore) SyncIDSequence(ctx context.Context) error {
+	query := `SELECT setval(pg_get_serial_sequence('applications', 'id'), COALESCE(MAX(id), 0) + 1, false) FROM applications`
+	_, err := s.db.ExecContext(ctx, query)
+	return err
+}
+
+// Read retrieves job applications matching the filter from the database with possible sorting by a specified field.
+func (s *JobApplicationsStore) Read(ctx context.Context, sortBy string, descending bool, filter Filter) ([]JobApplication, error) {
+	apps, _, err := s.ReadPage(ctx, sortBy, descending, filter, Page{})
+	return apps, err
+}
+
+// ReadPage retrieves a page of the job applications matching the filter, sorted by a specified field.
+// more reports whether further applications follow the page.
+func (s *JobApplicationsStore) ReadPage(ctx context.Context, sortBy string, descending bool, filter Filter, page Page) (apps []JobApplication, more bool, err error) {
 	if sortBy != "" {
 		// Validate column name to prevent SQL injection
 		if err := ValidateColumnName(sortBy); err != nil {
-			return nil, err
+			return nil, false, err
 		}
-		query += ` ORDER BY ` + sortBy
-		if descending {
-			query += ` DESC`
+	}
+	if err := page.validate(); err != nil {
+		return nil, false, err
+	}
+	b := queryBuilder{dialect: s.dialect}
+	if err := filter.build(&b); err != nil {
+		return nil, false, err
+	}
+	if page.After != nil {
+		if err := page.After.build(&b, sortBy, descending); err != nil {
+			return nil, false, err
 		}
 	}
-	rows, err := s.db.QueryContext(ctx, query)
+	// One application more than the limit is read to tell whether the page is the last one
+	window := page
+	if window.Limit > 0 {
+		window.Limit++
+	}
+	query := `SELECT id, company, position, status, created_at, updated_at FROM applications` +
+		b.where() + b.orderBy(sortBy, descending, !page.IsZero()) + b.limit(window)
+	apps, err = s.queryApplications(ctx, query, b.args...)
+	if err != nil {
+		return nil, false, err
+	}
+	if page.Limit > 0 && len(apps) > page.Limit {
+		return apps[:page.Limit], true, nil
+	}
+	return apps, false, nil
+}
+
+// Count returns the number of job applications matching the filter.
+func (s *JobApplicationsStore) Count(ctx context.Context, filter Filter) (int, error) {
+	b := queryBuilder{dialect: s.dialect}
+	if err := filter.build(&b); err != nil {
+		return 0, err
+	}
+	var count int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM applications`+b.where(), b.args...).Scan(&count)
+	return count, err
+}
+
+// Get retrieves a single job application along with its tags, or ErrNotFound if it does not exist.
+func (s *JobApplicationsStore) Get(ctx context.Context, id int) (JobApplication, error) {
+	query := `SELECT id, company, position, status, created_at, updated_at FROM applications WHERE id=$1`
+	apps, err := s.queryApplications(ctx, query, id)
+	if err != nil {
+		return JobApplication{}, err
+	}
+	if len(apps) == 0 {
+		return JobApplication{}, ErrNotFound
+	}
+	return apps[0], nil
+}
+
+// queryApplications runs a query over the applications table and scans the results along with their tags.
+func (s *JobApplicationsStore) queryApplications(ctx context.Context, query string, args ...any) ([]JobApplication, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -55,48 +232,157 @@ func (s *JobApplicationsStore) Read(ctx context.Context, sortBy string, descendi
 		}
 		applications = append(applications, app)
 	}
-	return applications, rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// Releasing the connection before loading tags
+	rows.Close()
+	if err := s.attachTags(ctx, applications); err != nil {
+		return nil, err
+	}
+	return applications, nil
 }
 
 // Update updates fields of a job application. Only provided fields are updated.
-func (s *JobApplicationsStore) Update(ctx context.Context, id int, fields map[string]string) (int64, error) {
-       if len(fields) == 0 {
-	       return 0, nil
-       }
-
-       // Validate all column names to prevent SQL injection
-       fieldNames := make([]string, 0, len(fields))
-       for k := range fields {
-	       fieldNames = append(fieldNames, k)
-       }
-       if err := ValidateColumnNames(fieldNames); err != nil {
-	       return 0, err
-       }
-
-       setClause := ""
-       args := make([]any, 0, len(fields)+1)
-       i := 1
-       for k, v := range fields {
-	       if setClause != "" {
-		       setClause += ", "
-	       }
-	       setClause += k + "=$" + strconv.Itoa(i)
-	       args = append(args, v)
-	       i++
-       }
-
-       setClause += ", updated_at=CURRENT_TIMESTAMP"
-       query := "UPDATE applications SET " + setClause + " WHERE id=$" + strconv.Itoa(i)
-       args = append(args, id)
-       res, err := s.db.ExecContext(ctx, query, args...)
-       if err != nil {
-	       return 0, err
-       }
-       rowsAffected, err := res.RowsAffected()
-       if err != nil {
-	       return 0, err
-       }
-       return rowsAffected, nil
+// Status changes must follow the workflow transitions unless force is set.
+func (s *JobApplicationsStore) Update(ctx context.Context, id int, fields map[string]string, force bool) (int64, error) {
+	return s.update(ctx, id, fields, force, `SELECT status FROM applications WHERE id=$1 FOR UPDATE`)
+}
+
+// update implements Update using statusQuery to read (and lock) the current status.
+func (s *JobApplicationsStore) update(ctx context.Context, id int, fields map[string]string, force bool, statusQuery string) (int64, error) {
+	if len(fields) == 0 {
+		return 0, nil
+	}
+	fields, err := normalizeFields(fields)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rowsAffected, err := s.updateFields(ctx, tx, id, fields, force, statusQuery)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return rowsAffected, nil
+}
+
+// UpdateApplication updates fields of a job application, then attaches and detaches tags,
+// all in a single transaction. Status changes must follow the workflow transitions unless force is set,
+// a rejected change being reported as a *TransitionError. Returns ErrNotFound if the application does not exist.
+func (s *JobApplicationsStore) UpdateApplication(ctx context.Context, id int, fields map[string]string, addTags, removeTags []string, force bool) error {
+	return s.updateApplication(ctx, id, fields, addTags, removeTags, force, `SELECT status FROM applications WHERE id=$1 FOR UPDATE`)
+}
+
+// updateApplication implements UpdateApplication using statusQuery to read (and lock) the current status.
+func (s *JobApplicationsStore) updateApplication(ctx context.Context, id int, fields map[string]string, addTags, removeTags []string, force bool, statusQuery string) error {
+	fields, err := normalizeFields(fields)
+	if err != nil {
+		return err
+	}
+	if addTags, err = NormalizeTags(addTags); err != nil {
+		return err
+	}
+	if removeTags, err = NormalizeTags(removeTags); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := checkApplicationExists(ctx, tx, id); err != nil {
+		return err
+	}
+	if len(fields) > 0 {
+		if _, err := s.updateFields(ctx, tx, id, fields, force, statusQuery); err != nil {
+			return err
+		}
+	}
+	if _, err := insertTags(ctx, tx, id, addTags); err != nil {
+		return err
+	}
+	if _, err := deleteTags(ctx, tx, id, removeTags); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// normalizeFields validates the column names of the fields to update and normalizes them,
+// so that status changes cannot bypass the workflow.
+func normalizeFields(fields map[string]string) (map[string]string, error) {
+	// Validate all column names to prevent SQL injection
+	fieldNames := make([]string, 0, len(fields))
+	for k := range fields {
+		fieldNames = append(fieldNames, k)
+	}
+	if err := ValidateColumnNames(fieldNames); err != nil {
+		return nil, err
+	}
+	normalized := make(map[string]string, len(fields))
+	for k, v := range fields {
+		normalized[strings.ToLower(strings.TrimSpace(k))] = v
+	}
+	return normalized, nil
+}
+
+// updateFields updates normalized fields of a job application within a transaction,
+// recording the status change if any. Returns the number of rows updated.
+func (s *JobApplicationsStore) updateFields(ctx context.Context, tx *sql.Tx, id int, fields map[string]string, force bool, statusQuery string) (int64, error) {
+	if newStatus, ok := fields["status"]; ok {
+		// Lock the row while validating the status transition
+		var current string
+		err := tx.QueryRowContext(ctx, statusQuery, id).Scan(&current)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		newStatus = strings.TrimSpace(newStatus)
+		if !force {
+			if newStatus, err = s.Workflow().ValidateTransition(current, newStatus); err != nil {
+				return 0, &TransitionError{Err: err}
+			}
+		}
+		fields["status"] = newStatus
+		if newStatus != current {
+			if err := recordStatusChange(ctx, tx, id, current, newStatus, time.Time{}); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	setClause := ""
+	args := make([]any, 0, len(fields)+1)
+	i := 1
+	for k, v := range fields {
+		if setClause != "" {
+			setClause += ", "
+		}
+		setClause += k + "=$" + strconv.Itoa(i)
+		args = append(args, v)
+		i++
+	}
+
+	setClause += ", updated_at=CURRENT_TIMESTAMP"
+	query := "UPDATE applications SET " + setClause + " WHERE id=$" + strconv.Itoa(i)
+	args = append(args, id)
+	res, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
 }
 
 // Delete deletes a job application from the database.
@@ -113,28 +399,77 @@ func (s *JobApplicationsStore) Delete(ctx context.Context, id int) (int64, error
 	return rowsAffected, nil
 }
 
-// Clear clears all job applications from the table and resets the ID sequence.
+// Clear clears all job applications (along with their dependent records) and resets the ID sequence.
 func (s *JobApplicationsStore) Clear(ctx context.Context) error {
-	query := `TRUNCATE TABLE applications RESTART IDENTITY`
+	query := `TRUNCATE TABLE applications RESTART IDENTITY CASCADE`
 	_, err := s.db.ExecContext(ctx, query)
 	return err
 }
 
-// Search searches for job applications matching the given keyword in company, position, or status.
+// DeleteMatching deletes the job applications matching the filter (along with their dependent records).
+func (s *JobApplicationsStore) DeleteMatching(ctx context.Context, filter Filter) (int64, error) {
+	b := queryBuilder{dialect: s.dialect}
+	if err := filter.build(&b); err != nil {
+		return 0, err
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM applications`+b.where(), b.args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// Search searches for job applications matching the given keyword in company, position, status, or notes.
 func (s *JobApplicationsStore) Search(ctx context.Context, keyword string) ([]JobApplication, error) {
-	query := `SELECT id, company, position, status, created_at, updated_at FROM applications WHERE company ILIKE $1 OR position ILIKE $1 OR status ILIKE $1`
-	rows, err := s.db.QueryContext(ctx, query, "%"+keyword+"%")
+	query := `SELECT id, company, position, status, created_at, updated_at FROM applications
+		WHERE company ILIKE $1 OR position ILIKE $1 OR status ILIKE $1
+		OR EXISTS (SELECT 1 FROM notes WHERE notes.application_id = applications.id AND notes.content ILIKE $1)`
+	return s.queryApplications(ctx, query, "%"+keyword+"%")
+}
+
+// History retrieves the status history of a job application in chronological order.
+func (s *JobApplicationsStore) History(ctx context.Context, id int) ([]StatusChange, error) {
+	query := `SELECT id, application_id, COALESCE(from_status, ''), to_status, changed_at
+		FROM status_history WHERE application_id=$1 ORDER BY changed_at, id`
+	return s.queryHistory(ctx, query, id)
+}
+
+// ReadHistory retrieves the status history of all job applications.
+func (s *JobApplicationsStore) ReadHistory(ctx context.Context) ([]StatusChange, error) {
+	query := `SELECT id, application_id, COALESCE(from_status, ''), to_status, changed_at
+		FROM status_history ORDER BY application_id, changed_at, id`
+	return s.queryHistory(ctx, query)
+}
+
+// queryHistory runs a query over the status history table and scans the results.
+func (s *JobApplicationsStore) queryHistory(ctx context.Context, query string, args ...any) ([]StatusChange, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var applications []JobApplication
+
+	var changes []StatusChange
 	for rows.Next() {
-		var app JobApplication
-		if err := rows.Scan(&app.ID, &app.Company, &app.Position, &app.Status, &app.CreatedAt, &app.UpdatedAt); err != nil {
+		var c StatusChange
+		if err := rows.Scan(&c.ID, &c.ApplicationID, &c.FromStatus, &c.ToStatus, &c.ChangedAt); err != nil {
 			return nil, err
 		}
-		applications = append(applications, app)
+		changes = append(changes, c)
 	}
-	return applications, rows.Err()
+	return changes, rows.Err()
+}
+
+// recordStatusChange inserts a status change into the history table within a transaction.
+// A zero changedAt time records the change at the current timestamp.
+func recordStatusChange(ctx context.Context, tx *sql.Tx, id int, from, to string, changedAt time.Time) error {
+	var fromStatus sql.NullString
+	if from != "" {
+		fromStatus = sql.NullString{String: from, Valid: true}
+	}
+	at := sql.NullTime{Time: changedAt, Valid: !changedAt.IsZero()}
+	query := `INSERT INTO status_history (application_id, from_status, to_status, changed_at)
+		VALUES ($1, $2, $3, COALESCE($4, CURRENT_TIMESTAMP))`
+	_, err := tx.ExecContext(ctx, query, id, fromStatus, to, at)
+	return err
 }
@@ -5,6 +5,7 @@ package db
 
 import (
 	"context"
+	"strings"
 	"testing"
 )
 
@@ -54,7 +55,7 @@ func TestReadSQLInjectionProtection(t *testing.T) {
 			store := &JobApplicationsStore{db: nil}
 			ctx := context.Background()
 
-			_, err := store.Read(ctx, tt.sortBy, false)
+			_, err := store.Read(ctx, tt.sortBy, false, Filter{})
 
 			if tt.expectValidation {
 				if err == nil {
@@ -131,7 +132,7 @@ func TestUpdateSQLInjectionProtection(t *testing.T) {
 			store := &JobApplicationsStore{db: nil}
 			ctx := context.Background()
 
-			_, err := store.Update(ctx, 1, tt.fields)
+			_, err := store.Update(ctx, 1, tt.fields, false)
 
 			if tt.expectValidation {
 				if err == nil {
@@ -150,3 +151,32 @@ func TestUpdateSQLInjectionProtection(t *testing.T) {
 		})
 	}
 }
+
+// TestAddStatusValidation tests that Add rejects statuses outside of the workflow
+// unless forced
+func TestAddStatusValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		wantErr bool
+	}{
+		{"unknown status", "Interviewing", true},
+		{"empty status", "   ", true},
+		{"injection attempt", "Applied'; DROP TABLE applications--", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &JobApplicationsStore{db: nil}
+			ctx := context.Background()
+
+			_, err := store.Add(ctx, "Google", "Engineer", tt.status, nil, false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Add() with status=%q error = %v, wantErr %v", tt.status, err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "status") {
+				t.Errorf("Add() error = %v, want error mentioning status", err)
+			}
+		})
+	}
+}
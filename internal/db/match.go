@@ -0,0 +1,103 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Ranks of company name matches, better matches ranking lower.
+const (
+	matchExact = iota
+	matchPrefix
+	matchSubstring
+	matchSubsequence
+	matchTypo
+	noMatch
+)
+
+// MatchCompany returns the applications whose company name matches the query best.
+// Names are compared case-insensitively, ignoring spaces and punctuation, and matches are ranked
+// from exact ones over prefixes, substrings and abbreviations (e.g. "gs" for "Goldman Sachs")
+// to names with a few typos. Only the applications of the best rank are returned.
+func MatchCompany(apps []JobApplication, query string) []JobApplication {
+	q := normalizeName(query)
+	if len(q) == 0 {
+		return nil
+	}
+	best := noMatch
+	var matches []JobApplication
+	for _, app := range apps {
+		rank := matchRank(normalizeName(app.Company), q)
+		switch {
+		case rank < best:
+			best, matches = rank, []JobApplication{app}
+		case rank == best && rank != noMatch:
+			matches = append(matches, app)
+		}
+	}
+	return matches
+}
+
+// normalizeName lowercases a name, keeping only its letters and digits.
+func normalizeName(name string) []rune {
+	var runes []rune
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			runes = append(runes, r)
+		}
+	}
+	return runes
+}
+
+// matchRank ranks how well a normalized name matches a normalized query.
+func matchRank(name, query []rune) int {
+	s, q := string(name), string(query)
+	switch {
+	case s == q:
+		return matchExact
+	case strings.HasPrefix(s, q):
+		return matchPrefix
+	case strings.Contains(s, q):
+		return matchSubstring
+	case isSubsequence(name, query):
+		return matchSubsequence
+	case editDistance(name, query) <= max(1, len(query)/3):
+		return matchTypo
+	}
+	return noMatch
+}
+
+// isSubsequence reports whether the runes of query appear in name in the same order.
+func isSubsequence(name, query []rune) bool {
+	i := 0
+	for _, r := range name {
+		if i < len(query) && r == query[i] {
+			i++
+		}
+	}
+	return i == len(query)
+}
+
+// editDistance computes the Levenshtein distance between two strings of runes.
+func editDistance(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,45 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMatchCompany tests that the best company name matches are returned
+func TestMatchCompany(t *testing.T) {
+	apps := []JobApplication{
+		{ID: 1, Company: "Google"},
+		{ID: 2, Company: "Goldman Sachs"},
+		{ID: 3, Company: "Acme Corp."},
+		{ID: 4, Company: "acme corp"},
+		{ID: 5, Company: "Deutsche Bank"},
+	}
+	tests := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{"exact ignoring case and punctuation", "ACME CORP", []int{3, 4}},
+		{"prefix over substring", "go", []int{1, 2}},
+		{"substring", "sachs", []int{2}},
+		{"abbreviation", "gs", []int{2}},
+		{"typo", "deutche bank", []int{5}},
+		{"transposed letters", "googel", []int{1}},
+		{"no match", "microsoft", nil},
+		{"empty query", " ", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, app := range MatchCompany(apps, tt.query) {
+				got = append(got, app.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchCompany(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
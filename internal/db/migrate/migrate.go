@@ -5,88 +5,550 @@ package migrate
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"database/sql/driver"
 	"embed"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"sort"
+	"strings"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
 )
 
-//go:embed migrations/*.sql
+// Migrations are kept per dialect under the same file names,
+// so that the recorded versions do not depend on the database.
+// Every version has an up script and a down script reverting it.
+//
+//go:embed migrations/*/*.sql
 var fs embed.FS
 
-// Run executes the defined migration scripts.
-func Run(ctx context.Context, db *sql.DB) error {
-	// Making sure schema_migrations table exists
-	if _, err := db.ExecContext(ctx, `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version TEXT PRIMARY KEY
-		)
-	`); err != nil {
-		return err
+const (
+	upSuffix   = ".up.sql"
+	downSuffix = ".down.sql"
+)
+
+// lockKey identifies the Postgres advisory lock serializing migrations ("jobtrack" in ASCII).
+const lockKey int64 = 0x6a6f62747261636b
+
+// Migration is a versioned pair of scripts changing the database schema.
+type Migration struct {
+	Version string
+	Up      string
+	Down    string
+}
+
+// Checksum returns the SHA-256 checksum of the up script, recorded when the migration is applied.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// Status describes whether a migration is applied to the database.
+type Status struct {
+	Version   string    `json:"version"`
+	Applied   bool      `json:"applied"`
+	AppliedAt time.Time `json:"applied_at,omitzero"`
+	// Checksum is the checksum recorded when the migration was applied.
+	Checksum string `json:"checksum,omitempty"`
+	// Modified reports that the embedded script no longer matches the recorded checksum.
+	Modified bool `json:"modified"`
+	// Unknown reports a migration recorded in the database but missing from this build.
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// Classification groups the versions of migrations by their state in the database.
+type Classification struct {
+	Applied  []string
+	Pending  []string
+	Modified []string
+	Unknown  []string
+}
+
+// Classify groups the statuses returned by Statuses by state. Modified and unknown
+// migrations are only listed as such, not as applied.
+func Classify(statuses []Status) Classification {
+	var c Classification
+	for _, status := range statuses {
+		switch {
+		case status.Unknown:
+			c.Unknown = append(c.Unknown, status.Version)
+		case status.Modified:
+			c.Modified = append(c.Modified, status.Version)
+		case status.Applied:
+			c.Applied = append(c.Applied, status.Version)
+		default:
+			c.Pending = append(c.Pending, status.Version)
+		}
 	}
+	return c
+}
 
-	// Reading applied migrations
-	rows, err := db.QueryContext(ctx,
-		`SELECT version FROM schema_migrations`,
-	)
-	if err != nil {
+// DriftError reports applied migrations whose scripts were changed after being applied.
+type DriftError struct {
+	Versions []string
+}
+
+func (e *DriftError) Error() string {
+	return fmt.Sprintf("applied migrations do not match their embedded scripts: %s "+
+		"(migrations must not be edited once applied, add a new migration instead)", strings.Join(e.Versions, ", "))
+}
+
+// record is an applied migration as stored in schema_migrations.
+type record struct {
+	AppliedAt time.Time
+	Checksum  string
+}
+
+// querier runs statements on a database or on a single connection holding the migration lock.
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// session is a querier along with the dialect of its database.
+type session struct {
+	q       querier
+	dialect db.Dialect
+}
+
+// Direction tells whether a step applies or reverts a migration.
+type Direction string
+
+const (
+	DirectionUp   Direction = "up"
+	DirectionDown Direction = "down"
+)
+
+// Step is a migration to apply or revert.
+type Step struct {
+	Migration Migration
+	Direction Direction
+}
+
+// SQL returns the script executed by the step.
+func (s Step) SQL() string {
+	if s.Direction == DirectionDown {
+		return s.Migration.Down
+	}
+	return s.Migration.Up
+}
+
+// Options controls how migration steps are executed.
+type Options struct {
+	// DryRun prints the SQL of the steps to Out instead of executing it.
+	DryRun bool
+	Out    io.Writer
+}
+
+// Run executes the pending migration scripts for the dialect of the database.
+func Run(ctx context.Context, conn *sql.DB) error {
+	_, err := Up(ctx, conn, Options{})
+	return err
+}
+
+// Up applies all pending migrations and returns the executed steps.
+func Up(ctx context.Context, conn *sql.DB, opts Options) ([]Step, error) {
+	var steps []Step
+	err := withLock(ctx, conn, func(s session) error {
+		migrations, applied, err := load(ctx, s)
+		if err != nil {
+			return err
+		}
+		steps, err = execute(ctx, s, migrations, plan(migrations, applied, len(migrations)-1), opts)
 		return err
+	})
+	return steps, err
+}
+
+// Down reverts the last steps applied migrations and returns the executed steps.
+func Down(ctx context.Context, conn *sql.DB, steps int, opts Options) ([]Step, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("number of steps must be at least 1")
 	}
-	defer rows.Close()
+	var executed []Step
+	err := withLock(ctx, conn, func(s session) error {
+		migrations, applied, err := load(ctx, s)
+		if err != nil {
+			return err
+		}
+		var plan []Step
+		for i := len(migrations) - 1; i >= 0 && len(plan) < steps; i-- {
+			if _, ok := applied[migrations[i].Version]; ok {
+				plan = append(plan, Step{Migration: migrations[i], Direction: DirectionDown})
+			}
+		}
+		executed, err = execute(ctx, s, migrations, plan, opts)
+		return err
+	})
+	return executed, err
+}
 
-	applied := map[string]bool{}
-	for rows.Next() {
-		var v string
-		if err := rows.Scan(&v); err != nil {
+// To migrates the database up or down to a version and returns the executed steps.
+// The version may be given in full ("002_status_history") or by number ("002").
+// Version "0" reverts all migrations.
+func To(ctx context.Context, conn *sql.DB, version string, opts Options) ([]Step, error) {
+	var steps []Step
+	err := withLock(ctx, conn, func(s session) error {
+		migrations, applied, err := load(ctx, s)
+		if err != nil {
+			return err
+		}
+		target, err := findVersion(migrations, version)
+		if err != nil {
 			return err
 		}
-		applied[v] = true
+		steps, err = execute(ctx, s, migrations, plan(migrations, applied, target), opts)
+		return err
+	})
+	return steps, err
+}
+
+// Statuses lists all known migrations in order along with their state in the database.
+// Migrations recorded in the database but unknown to this build are listed last.
+func Statuses(ctx context.Context, conn *sql.DB) ([]Status, error) {
+	s := session{q: conn, dialect: db.DialectOf(conn)}
+	migrations, err := Load(s.dialect)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := readApplied(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(migrations))
+	known := make(map[string]bool, len(migrations))
+	for _, m := range migrations {
+		known[m.Version] = true
+		r, ok := applied[m.Version]
+		statuses = append(statuses, Status{
+			Version:   m.Version,
+			Applied:   ok,
+			AppliedAt: r.AppliedAt,
+			Checksum:  r.Checksum,
+			Modified:  ok && r.Checksum != "" && r.Checksum != m.Checksum(),
+		})
+	}
+	var unknown []string
+	for version := range applied {
+		if !known[version] {
+			unknown = append(unknown, version)
+		}
+	}
+	sort.Strings(unknown)
+	for _, version := range unknown {
+		r := applied[version]
+		statuses = append(statuses, Status{Version: version, Applied: true, AppliedAt: r.AppliedAt, Checksum: r.Checksum, Unknown: true})
 	}
+	return statuses, nil
+}
 
-	// Reading embedded files
-	entries, err := fs.ReadDir("migrations")
+// Verify checks that the applied migrations match their embedded scripts,
+// returning a *DriftError listing the migrations changed after being applied.
+// Migrations applied by older releases without a recorded checksum are not verified.
+func Verify(ctx context.Context, conn *sql.DB) error {
+	s := session{q: conn, dialect: db.DialectOf(conn)}
+	migrations, err := Load(s.dialect)
+	if err != nil {
+		return err
+	}
+	applied, err := readApplied(ctx, s)
 	if err != nil {
 		return err
 	}
+	return verify(migrations, applied)
+}
 
-	var files []string
+// Load reads the embedded migrations of a dialect in version order.
+func Load(dialect db.Dialect) ([]Migration, error) {
+	dir := "migrations/" + string(dialect)
+	entries, err := fs.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[string]*Migration{}
 	for _, e := range entries {
-		files = append(files, e.Name())
+		name := e.Name()
+		version, isUp := strings.CutSuffix(name, upSuffix)
+		if !isUp {
+			var isDown bool
+			if version, isDown = strings.CutSuffix(name, downSuffix); !isDown {
+				return nil, fmt.Errorf("migration %s must end with %s or %s", name, upSuffix, downSuffix)
+			}
+		}
+		script, err := fs.ReadFile(dir + "/" + name)
+		if err != nil {
+			return nil, err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version}
+			byVersion[version] = m
+		}
+		if isUp {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %s must have both %s and %s scripts", m.Version, upSuffix, downSuffix)
+		}
+		migrations = append(migrations, *m)
 	}
-	sort.Strings(files)
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
 
-	// Applying missing migrations
-	for _, name := range files {
-		if applied[name] {
-			continue
+// withLock runs fn with exclusive access to the migrations of the database.
+// On Postgres, a session-level advisory lock is held on a dedicated connection
+// for the duration of fn, so that concurrent runs wait for each other.
+// SQLite serializes writers itself through the locks of the database file.
+func withLock(ctx context.Context, conn *sql.DB, fn func(s session) error) error {
+	dialect := db.DialectOf(conn)
+	if dialect != db.Postgres {
+		return fn(session{q: conn, dialect: dialect})
+	}
+	c, err := conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if _, err := c.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, lockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := c.ExecContext(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, lockKey); err != nil {
+			// The lock is released along with the connection, which must not be reused
+			c.Raw(func(any) error { return driver.ErrBadConn })
 		}
+	}()
+	return fn(session{q: c, dialect: dialect})
+}
 
-		sqlBytes, err := fs.ReadFile("migrations/" + name)
+// load reads the embedded migrations and the applied versions,
+// failing if any applied migration was changed since.
+func load(ctx context.Context, s session) ([]Migration, map[string]record, error) {
+	migrations, err := Load(s.dialect)
+	if err != nil {
+		return nil, nil, err
+	}
+	applied, err := readApplied(ctx, s)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := verify(migrations, applied); err != nil {
+		return nil, nil, err
+	}
+	return migrations, applied, nil
+}
+
+// verify compares the recorded checksums of applied migrations with the embedded scripts.
+func verify(migrations []Migration, applied map[string]record) error {
+	var drifted []string
+	for _, m := range migrations {
+		if r, ok := applied[m.Version]; ok && r.Checksum != "" && r.Checksum != m.Checksum() {
+			drifted = append(drifted, m.Version)
+		}
+	}
+	if len(drifted) > 0 {
+		return &DriftError{Versions: drifted}
+	}
+	return nil
+}
+
+// readApplied reads the applied versions from schema_migrations, if the table exists.
+// Versions recorded as file names by older releases (e.g. "001_init.sql") are normalized,
+// and columns missing from their tables are read as empty.
+func readApplied(ctx context.Context, s session) (map[string]record, error) {
+	applied := map[string]record{}
+	exists, err := tableExists(ctx, s, "schema_migrations")
+	if err != nil || !exists {
+		return applied, err
+	}
+	columns := "version"
+	for _, column := range []string{"applied_at", "checksum"} {
+		ok, err := columnExists(ctx, s, "schema_migrations", column)
 		if err != nil {
-			return err
+			return nil, err
+		}
+		if !ok {
+			column = "NULL"
+		}
+		columns += ", " + column
+	}
+	rows, err := s.q.QueryContext(ctx, `SELECT `+columns+` FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var version string
+		var appliedAt sql.NullTime
+		var checksum sql.NullString
+		if err := rows.Scan(&version, &appliedAt, &checksum); err != nil {
+			return nil, err
 		}
+		applied[strings.TrimSuffix(version, ".sql")] = record{AppliedAt: appliedAt.Time, Checksum: checksum.String}
+	}
+	return applied, rows.Err()
+}
 
-		tx, err := db.BeginTx(ctx, nil)
+// prepare creates schema_migrations or upgrades it from the format of older releases.
+func prepare(ctx context.Context, s session, migrations []Migration) error {
+	timestampType := "TIMESTAMP WITH TIME ZONE"
+	if s.dialect == db.SQLite {
+		timestampType = "TIMESTAMP"
+	}
+	if _, err := s.q.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version TEXT PRIMARY KEY,
+			applied_at `+timestampType+`,
+			checksum TEXT
+		)
+	`); err != nil {
+		return err
+	}
+	// Tables created by older releases lack the columns added since,
+	// leaving the time of the migrations applied before empty
+	for _, column := range [][2]string{{"applied_at", timestampType}, {"checksum", "TEXT"}} {
+		ok, err := columnExists(ctx, s, "schema_migrations", column[0])
 		if err != nil {
 			return err
 		}
-
-		if _, err := tx.ExecContext(ctx, string(sqlBytes)); err != nil {
-			tx.Rollback()
+		if !ok {
+			if _, err := s.q.ExecContext(ctx, `ALTER TABLE schema_migrations ADD COLUMN `+column[0]+` `+column[1]); err != nil {
+				return err
+			}
+		}
+	}
+	if _, err := s.q.ExecContext(ctx, `UPDATE schema_migrations
+		SET version = substr(version, 1, length(version) - 4) WHERE version LIKE '%.sql'`); err != nil {
+		return err
+	}
+	// Checksums of migrations applied before are taken from the embedded scripts,
+	// so that changes made from now on are detected
+	for _, m := range migrations {
+		if _, err := s.q.ExecContext(ctx, `UPDATE schema_migrations SET checksum = $1
+			WHERE version = $2 AND checksum IS NULL`, m.Checksum(), m.Version); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		if _, err := tx.ExecContext(ctx,
-			`INSERT INTO schema_migrations (version) VALUES ($1)`,
-			name,
-		); err != nil {
-			tx.Rollback()
-			return err
+// tableExists checks if a table exists in the database.
+func tableExists(ctx context.Context, s session, table string) (bool, error) {
+	query := `SELECT EXISTS (
+		SELECT 1 FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_name = $1
+	)`
+	if s.dialect == db.SQLite {
+		query = `SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = $1)`
+	}
+	var exists bool
+	err := s.q.QueryRowContext(ctx, query, table).Scan(&exists)
+	return exists, err
+}
+
+// columnExists checks if a table has a column.
+func columnExists(ctx context.Context, s session, table, column string) (bool, error) {
+	query := `SELECT EXISTS (
+		SELECT 1 FROM information_schema.columns
+		WHERE table_schema = 'public' AND table_name = $1 AND column_name = $2
+	)`
+	if s.dialect == db.SQLite {
+		query = `SELECT EXISTS (SELECT 1 FROM pragma_table_info($1) WHERE name = $2)`
+	}
+	var exists bool
+	err := s.q.QueryRowContext(ctx, query, table, column).Scan(&exists)
+	return exists, err
+}
+
+// plan returns the steps bringing the database to the migration at index target
+// (-1 reverting all migrations): pending migrations up to the target are applied
+// and applied migrations past it are reverted, latest first.
+func plan(migrations []Migration, applied map[string]record, target int) []Step {
+	var steps []Step
+	for i := len(migrations) - 1; i > target; i-- {
+		if _, ok := applied[migrations[i].Version]; ok {
+			steps = append(steps, Step{Migration: migrations[i], Direction: DirectionDown})
 		}
+	}
+	for i := 0; i <= target; i++ {
+		if _, ok := applied[migrations[i].Version]; !ok {
+			steps = append(steps, Step{Migration: migrations[i], Direction: DirectionUp})
+		}
+	}
+	return steps
+}
 
-		if err := tx.Commit(); err != nil {
-			return err
+// findVersion returns the index of a migration by full version or number, or -1 for version "0".
+func findVersion(migrations []Migration, version string) (int, error) {
+	version = strings.TrimSpace(version)
+	if strings.Trim(version, "0") == "" && version != "" {
+		return -1, nil
+	}
+	for i, m := range migrations {
+		number, _, _ := strings.Cut(m.Version, "_")
+		if m.Version == version || number == version {
+			return i, nil
 		}
 	}
+	return 0, fmt.Errorf("unknown migration version: %q", version)
+}
 
-	return nil
+// execute runs the steps, each in its own transaction along with its record in schema_migrations.
+// In dry-run mode, the SQL of the steps is printed instead.
+func execute(ctx context.Context, s session, migrations []Migration, steps []Step, opts Options) ([]Step, error) {
+	if opts.DryRun {
+		for _, step := range steps {
+			if _, err := fmt.Fprintf(opts.Out, "-- %s %s\n%s\n", step.Direction, step.Migration.Version, strings.TrimSpace(step.SQL())); err != nil {
+				return nil, err
+			}
+		}
+		return steps, nil
+	}
+	if err := prepare(ctx, s, migrations); err != nil {
+		return nil, err
+	}
+	for i, step := range steps {
+		if err := executeStep(ctx, s, step); err != nil {
+			return steps[:i], fmt.Errorf("migration %s %s failed: %w", step.Migration.Version, step.Direction, err)
+		}
+	}
+	return steps, nil
+}
+
+// executeStep runs a single step in a transaction.
+func executeStep(ctx context.Context, s session, step Step) error {
+	tx, err := s.q.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, step.SQL()); err != nil {
+		return err
+	}
+	if step.Direction == DirectionDown {
+		_, err = tx.ExecContext(ctx, `DELETE FROM schema_migrations WHERE version = $1`, step.Migration.Version)
+	} else {
+		_, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version, applied_at, checksum)
+			VALUES ($1, CURRENT_TIMESTAMP, $2)`, step.Migration.Version, step.Migration.Checksum())
+	}
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
 }
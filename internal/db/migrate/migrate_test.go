@@ -0,0 +1,257 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+)
+
+// openSQLite opens an empty SQLite database in a temporary directory
+func openSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	cfg := &config.ConnectionConfig{Driver: config.DriverSQLite, DBPath: filepath.Join(t.TempDir(), "jobtracker.db")}
+	conn, err := db.Connect(context.Background(), cfg, "")
+	if err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// appliedVersions returns the applied versions in order
+func appliedVersions(t *testing.T, conn *sql.DB) []string {
+	t.Helper()
+	statuses, err := Statuses(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("Statuses() error = %v", err)
+	}
+	var versions []string
+	for _, s := range statuses {
+		if s.Applied {
+			versions = append(versions, s.Version)
+		}
+	}
+	return versions
+}
+
+func TestLoad(t *testing.T) {
+	for _, dialect := range []db.Dialect{db.Postgres, db.SQLite} {
+		t.Run(string(dialect), func(t *testing.T) {
+			migrations, err := Load(dialect)
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if len(migrations) == 0 || migrations[0].Version != "001_init" {
+				t.Fatalf("Load() = %+v, want migrations starting with 001_init", migrations)
+			}
+			for i, m := range migrations {
+				if m.Up == "" || m.Down == "" {
+					t.Errorf("migration %s is missing a script", m.Version)
+				}
+				if i > 0 && migrations[i-1].Version >= m.Version {
+					t.Errorf("migrations are not sorted: %s before %s", migrations[i-1].Version, m.Version)
+				}
+			}
+		})
+	}
+
+	postgres, _ := Load(db.Postgres)
+	sqlite, _ := Load(db.SQLite)
+	if len(postgres) != len(sqlite) {
+		t.Fatalf("dialects have %d and %d migrations, want the same versions", len(postgres), len(sqlite))
+	}
+	for i := range postgres {
+		if postgres[i].Version != sqlite[i].Version {
+			t.Errorf("version %d differs between dialects: %s and %s", i, postgres[i].Version, sqlite[i].Version)
+		}
+	}
+}
+
+func TestUpDownTo(t *testing.T) {
+	conn := openSQLite(t)
+	ctx := context.Background()
+	migrations, _ := Load(db.SQLite)
+	last := migrations[len(migrations)-1].Version
+
+	steps, err := Up(ctx, conn, Options{})
+	if err != nil || len(steps) != len(migrations) {
+		t.Fatalf("Up() = %d steps, %v, want %d", len(steps), err, len(migrations))
+	}
+	statuses, _ := Statuses(ctx, conn)
+	for _, s := range statuses {
+		if !s.Applied || s.AppliedAt.IsZero() {
+			t.Errorf("Statuses() after Up() = %+v, want applied with timestamp", s)
+		}
+	}
+	if steps, err := Up(ctx, conn, Options{}); err != nil || len(steps) != 0 {
+		t.Errorf("second Up() = %d steps, %v, want none", len(steps), err)
+	}
+
+	steps, err = Down(ctx, conn, 2, Options{})
+	if err != nil || len(steps) != 2 || steps[0].Migration.Version != last || steps[0].Direction != DirectionDown {
+		t.Fatalf("Down(2) = %+v, %v, want the latest two reverted", steps, err)
+	}
+	if got := appliedVersions(t, conn); len(got) != len(migrations)-2 {
+		t.Errorf("applied after Down(2) = %v", got)
+	}
+
+	if _, err := To(ctx, conn, "002", Options{}); err != nil {
+		t.Fatalf("To(002) error = %v", err)
+	}
+	if got := strings.Join(appliedVersions(t, conn), ","); got != "001_init,002_status_history" {
+		t.Errorf("applied after To(002) = %s", got)
+	}
+	if exists, _ := db.CheckTableExists(ctx, conn, "notes"); exists {
+		t.Error("notes table exists after migrating down to 002")
+	}
+
+	if _, err := To(ctx, conn, last, Options{}); err != nil {
+		t.Fatalf("To(%s) error = %v", last, err)
+	}
+	if got := appliedVersions(t, conn); len(got) != len(migrations) {
+		t.Errorf("applied after To(%s) = %v", last, got)
+	}
+
+	if _, err := To(ctx, conn, "0", Options{}); err != nil {
+		t.Fatalf("To(0) error = %v", err)
+	}
+	if got := appliedVersions(t, conn); len(got) != 0 {
+		t.Errorf("applied after To(0) = %v, want none", got)
+	}
+	if _, err := To(ctx, conn, "999", Options{}); err == nil {
+		t.Error("To() with unknown version should return error")
+	}
+	if _, err := Down(ctx, conn, 0, Options{}); err == nil {
+		t.Error("Down(0) should return error")
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	conn := openSQLite(t)
+	ctx := context.Background()
+
+	var out bytes.Buffer
+	steps, err := Up(ctx, conn, Options{DryRun: true, Out: &out})
+	if err != nil || len(steps) == 0 {
+		t.Fatalf("Up() dry run = %d steps, %v", len(steps), err)
+	}
+	if !strings.Contains(out.String(), "-- up 001_init\nCREATE TABLE IF NOT EXISTS applications") {
+		t.Errorf("Up() dry run printed %q, want the SQL of 001_init", out.String())
+	}
+	if exists, _ := db.CheckTableExists(ctx, conn, "schema_migrations"); exists {
+		t.Error("dry run created schema_migrations")
+	}
+}
+
+func TestLegacyVersions(t *testing.T) {
+	conn := openSQLite(t)
+	ctx := context.Background()
+
+	// Older releases recorded versions as file names, without applied_at
+	legacy := `CREATE TABLE schema_migrations (version TEXT PRIMARY KEY);
+		INSERT INTO schema_migrations (version) VALUES ('001_init.sql');`
+	if _, err := conn.ExecContext(ctx, legacy); err != nil {
+		t.Fatal(err)
+	}
+	migrations, _ := Load(db.SQLite)
+	if _, err := conn.ExecContext(ctx, migrations[0].Up); err != nil {
+		t.Fatal(err)
+	}
+
+	steps, err := Up(ctx, conn, Options{})
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if len(steps) != len(migrations)-1 || steps[0].Migration.Version == "001_init" {
+		t.Errorf("Up() = %+v, want 001_init to be treated as applied", steps)
+	}
+	statuses, _ := Statuses(ctx, conn)
+	if statuses[0].Version != "001_init" || !statuses[0].Applied || !statuses[0].AppliedAt.IsZero() {
+		t.Errorf("Statuses()[0] = %+v, want 001_init applied at an unknown time", statuses[0])
+	}
+	if statuses[0].Checksum != migrations[0].Checksum() {
+		t.Errorf("Statuses()[0].Checksum = %q, want checksum recorded from the embedded script", statuses[0].Checksum)
+	}
+}
+
+func TestChecksums(t *testing.T) {
+	conn := openSQLite(t)
+	ctx := context.Background()
+	if _, err := Up(ctx, conn, Options{}); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	migrations, _ := Load(db.SQLite)
+	statuses, _ := Statuses(ctx, conn)
+	for i, s := range statuses {
+		if s.Checksum != migrations[i].Checksum() || s.Modified {
+			t.Errorf("Statuses()[%d] = %+v, want checksum %s", i, s, migrations[i].Checksum())
+		}
+	}
+	if err := Verify(ctx, conn); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+
+	// Simulate the script of an applied migration being edited afterwards
+	if _, err := conn.ExecContext(ctx, `UPDATE schema_migrations SET checksum = 'edited' WHERE version = '002_status_history'`); err != nil {
+		t.Fatal(err)
+	}
+	var drift *DriftError
+	if err := Verify(ctx, conn); !errors.As(err, &drift) || strings.Join(drift.Versions, ",") != "002_status_history" {
+		t.Errorf("Verify() error = %v, want drift of 002_status_history", err)
+	}
+	if _, err := Down(ctx, conn, 1, Options{}); !errors.As(err, &drift) {
+		t.Errorf("Down() error = %v, want drift error", err)
+	}
+	if got := appliedVersions(t, conn); len(got) != len(migrations) {
+		t.Errorf("Down() reverted migrations despite drift: applied = %v", got)
+	}
+	statuses, _ = Statuses(ctx, conn)
+	if !statuses[1].Modified || statuses[0].Modified {
+		t.Errorf("Statuses() = %+v, want only 002_status_history modified", statuses[:2])
+	}
+}
+
+func TestClassify(t *testing.T) {
+	conn := openSQLite(t)
+	ctx := context.Background()
+	if _, err := To(ctx, conn, "003", Options{}); err != nil {
+		t.Fatalf("To() error = %v", err)
+	}
+	// Simulate an edited script and a migration applied by a newer release
+	if _, err := conn.ExecContext(ctx, `UPDATE schema_migrations SET checksum = 'edited' WHERE version = '002_status_history'`); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ExecContext(ctx, `INSERT INTO schema_migrations (version, checksum) VALUES ('999_future', 'x')`); err != nil {
+		t.Fatal(err)
+	}
+	statuses, err := Statuses(ctx, conn)
+	if err != nil {
+		t.Fatalf("Statuses() error = %v", err)
+	}
+
+	c := Classify(statuses)
+	for _, tt := range []struct {
+		name string
+		got  []string
+		want string
+	}{
+		{"applied", c.Applied, "001_init,003_notes"},
+		{"pending", c.Pending, "004_tags,005_follow_ups"},
+		{"modified", c.Modified, "002_status_history"},
+		{"unknown", c.Unknown, "999_future"},
+	} {
+		if got := strings.Join(tt.got, ","); got != tt.want {
+			t.Errorf("Classify() %s = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
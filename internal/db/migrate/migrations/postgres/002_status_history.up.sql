@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS status_history (
+		id SERIAL PRIMARY KEY,
+		application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+		from_status VARCHAR(255),
+		to_status VARCHAR(255) NOT NULL,
+		changed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+	);
+
+CREATE INDEX IF NOT EXISTS idx_status_history_application_id ON status_history (application_id);
+
+-- Seeding history with the current status of existing applications
+INSERT INTO status_history (application_id, from_status, to_status, changed_at)
+	SELECT id, NULL, status, created_at FROM applications;
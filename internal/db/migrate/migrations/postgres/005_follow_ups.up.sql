@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS follow_ups (
+		id SERIAL PRIMARY KEY,
+		application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+		due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+		done_at TIMESTAMP WITH TIME ZONE,
+		created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+	);
+
+CREATE INDEX IF NOT EXISTS idx_follow_ups_application_id ON follow_ups (application_id);
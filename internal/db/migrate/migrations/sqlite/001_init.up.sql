@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS applications (
+		id INTEGER PRIMARY KEY,
+		company VARCHAR(255) NOT NULL,
+		position VARCHAR(255) NOT NULL,
+		status VARCHAR(255) NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
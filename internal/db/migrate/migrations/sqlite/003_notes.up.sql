@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS notes (
+		id INTEGER PRIMARY KEY,
+		application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+		content TEXT NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+		updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+CREATE INDEX IF NOT EXISTS idx_notes_application_id ON notes (application_id);
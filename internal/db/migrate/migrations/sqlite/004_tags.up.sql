@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS tags (
+		id INTEGER PRIMARY KEY,
+		name VARCHAR(64) NOT NULL UNIQUE
+	);
+
+CREATE TABLE IF NOT EXISTS application_tags (
+		application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+		tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+		PRIMARY KEY (application_id, tag_id)
+	);
+
+CREATE INDEX IF NOT EXISTS idx_application_tags_tag_id ON application_tags (tag_id);
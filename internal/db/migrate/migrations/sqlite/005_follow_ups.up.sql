@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS follow_ups (
+		id INTEGER PRIMARY KEY,
+		application_id INTEGER NOT NULL REFERENCES applications(id) ON DELETE CASCADE,
+		due_at TIMESTAMP NOT NULL,
+		done_at TIMESTAMP,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	);
+
+CREATE INDEX IF NOT EXISTS idx_follow_ups_application_id ON follow_ups (application_id);
@@ -16,6 +16,8 @@ type JobApplication struct {
 	Status    string    `json:"status"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	Tags      []string  `json:"tags,omitempty"`
+	Notes     []Note    `json:"notes,omitempty"`
 }
 
 // ConvertToStringSlice converts a JobApplication to a slice of strings for display.
@@ -29,3 +31,47 @@ func (app JobApplication) ConvertToStringSlice() []string {
 		app.UpdatedAt.Format(time.RFC3339),
 	}
 }
+
+// StatusChange represents a single status change of a job application.
+type StatusChange struct {
+	ID            int       `json:"id"`
+	ApplicationID int       `json:"application_id"`
+	FromStatus    string    `json:"from_status"`
+	ToStatus      string    `json:"to_status"`
+	ChangedAt     time.Time `json:"changed_at"`
+}
+
+// Note represents a free-form note attached to a job application.
+type Note struct {
+	ID            int       `json:"id"`
+	ApplicationID int       `json:"application_id"`
+	Content       string    `json:"content"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ApplicationDetails is a job application along with its notes and status history.
+type ApplicationDetails struct {
+	JobApplication
+	History []StatusChange `json:"history"`
+}
+
+// AttachNotes assigns notes to the job applications they belong to.
+func AttachNotes(apps []JobApplication, notes []Note) {
+	index := make(map[int]int, len(apps))
+	for i, app := range apps {
+		index[app.ID] = i
+	}
+	for _, note := range notes {
+		if i, ok := index[note.ApplicationID]; ok {
+			apps[i].Notes = append(apps[i].Notes, note)
+		}
+	}
+}
+
+// Tag represents a tag along with the number of applications carrying it.
+type Tag struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Applications int    `json:"applications"`
+}
@@ -301,3 +301,25 @@ func BenchmarkConvertToStringSlice_LargeID(b *testing.B) {
 		_ = app.ConvertToStringSlice()
 	}
 }
+
+func TestAttachNotes(t *testing.T) {
+	apps := []JobApplication{{ID: 1}, {ID: 2}, {ID: 3}}
+	notes := []Note{
+		{ID: 10, ApplicationID: 2, Content: "first"},
+		{ID: 11, ApplicationID: 2, Content: "second"},
+		{ID: 12, ApplicationID: 3, Content: "third"},
+		{ID: 13, ApplicationID: 99, Content: "orphan"},
+	}
+
+	AttachNotes(apps, notes)
+
+	if len(apps[0].Notes) != 0 {
+		t.Errorf("application 1 has notes %+v, want none", apps[0].Notes)
+	}
+	if len(apps[1].Notes) != 2 || apps[1].Notes[0].ID != 10 || apps[1].Notes[1].ID != 11 {
+		t.Errorf("application 2 has notes %+v, want notes 10 and 11 in order", apps[1].Notes)
+	}
+	if len(apps[2].Notes) != 1 || apps[2].Notes[0].ID != 12 {
+		t.Errorf("application 3 has notes %+v, want note 12", apps[2].Notes)
+	}
+}
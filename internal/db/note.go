@@ -0,0 +1,117 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// validateNoteContent checks that a note has some text.
+func validateNoteContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return fmt.Errorf("note content cannot be empty")
+	}
+	return nil
+}
+
+// AddNote attaches a new note to a job application.
+// Returns the ID of the note, or 0 if no application with the given ID exists.
+func (s *JobApplicationsStore) AddNote(ctx context.Context, applicationID int, content string) (int, error) {
+	if err := validateNoteContent(content); err != nil {
+		return 0, err
+	}
+	var id int
+	query := `INSERT INTO notes (application_id, content)
+		SELECT id, CAST($2 AS TEXT) FROM applications WHERE id=$1
+		RETURNING id`
+	err := s.db.QueryRowContext(ctx, query, applicationID, content).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// ReadNotes retrieves the notes of a job application in chronological order.
+func (s *JobApplicationsStore) ReadNotes(ctx context.Context, applicationID int) ([]Note, error) {
+	query := `SELECT id, application_id, content, created_at, updated_at
+		FROM notes WHERE application_id=$1 ORDER BY created_at, id`
+	return s.queryNotes(ctx, query, applicationID)
+}
+
+// ReadAllNotes retrieves the notes of all job applications.
+func (s *JobApplicationsStore) ReadAllNotes(ctx context.Context) ([]Note, error) {
+	query := `SELECT id, application_id, content, created_at, updated_at
+		FROM notes ORDER BY application_id, created_at, id`
+	return s.queryNotes(ctx, query)
+}
+
+// UpdateNote replaces the content of a note.
+func (s *JobApplicationsStore) UpdateNote(ctx context.Context, id int, content string) (int64, error) {
+	if err := validateNoteContent(content); err != nil {
+		return 0, err
+	}
+	query := `UPDATE notes SET content=$1, updated_at=CURRENT_TIMESTAMP WHERE id=$2`
+	res, err := s.db.ExecContext(ctx, query, content, id)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// DeleteNote deletes a note.
+func (s *JobApplicationsStore) DeleteNote(ctx context.Context, id int) (int64, error) {
+	query := `DELETE FROM notes WHERE id=$1`
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// queryNotes runs a query over the notes table and scans the results.
+func (s *JobApplicationsStore) queryNotes(ctx context.Context, query string, args ...any) ([]Note, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notes []Note
+	for rows.Next() {
+		var n Note
+		if err := rows.Scan(&n.ID, &n.ApplicationID, &n.Content, &n.CreatedAt, &n.UpdatedAt); err != nil {
+			return nil, err
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
+// insertNotes inserts imported notes of an application within a transaction,
+// keeping their timestamps if requested.
+func insertNotes(ctx context.Context, tx *sql.Tx, applicationID int, notes []Note, keepTimestamps bool) error {
+	for _, note := range notes {
+		if err := validateNoteContent(note.Content); err != nil {
+			return err
+		}
+		var createdAt, updatedAt sql.NullTime
+		if keepTimestamps {
+			createdAt = sql.NullTime{Time: note.CreatedAt, Valid: !note.CreatedAt.IsZero()}
+			updatedAt = sql.NullTime{Time: note.UpdatedAt, Valid: !note.UpdatedAt.IsZero()}
+		}
+		query := `INSERT INTO notes (application_id, content, created_at, updated_at)
+			VALUES ($1, $2, COALESCE($3, CURRENT_TIMESTAMP), COALESCE($4, CURRENT_TIMESTAMP))`
+		if _, err := tx.ExecContext(ctx, query, applicationID, note.Content, createdAt, updatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
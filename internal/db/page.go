@@ -0,0 +1,145 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Page selects a window of the job applications returned by ReadPage.
+// The zero value selects all applications.
+type Page struct {
+	// Limit is the maximum number of applications to return (0 means no limit).
+	Limit int
+	// Offset is the number of applications to skip.
+	Offset int
+	// After continues right after the application the cursor points at (keyset pagination).
+	After *Cursor
+}
+
+// IsZero reports whether the page selects all applications.
+func (p Page) IsZero() bool {
+	return p.Limit == 0 && p.Offset == 0 && p.After == nil
+}
+
+// Cursor points at a job application within a sorted listing.
+type Cursor struct {
+	// Value is the value of the sort column of the application (empty when sorting by ID).
+	Value string `json:"v,omitempty"`
+	// ID is the ID of the application, breaking ties between equal sort values.
+	ID int `json:"id"`
+}
+
+// CursorOf returns the cursor pointing at an application in a listing sorted by sortBy.
+func CursorOf(app JobApplication, sortBy string) Cursor {
+	c := Cursor{ID: app.ID}
+	switch normalizeColumn(sortBy) {
+	case "company":
+		c.Value = app.Company
+	case "position":
+		c.Value = app.Position
+	case "status":
+		c.Value = app.Status
+	case "created_at":
+		c.Value = app.CreatedAt.UTC().Format(time.RFC3339Nano)
+	case "updated_at":
+		c.Value = app.UpdatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return c
+}
+
+// String encodes the cursor into an opaque token.
+func (c Cursor) String() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor decodes a token produced by Cursor.String.
+func ParseCursor(token string) (Cursor, error) {
+	var c Cursor
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("invalid cursor: %q", token)
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 {
+		return c, fmt.Errorf("invalid cursor: %q", token)
+	}
+	return c, nil
+}
+
+// build adds the condition selecting the applications after the cursor in a listing sorted by sortBy.
+func (c Cursor) build(b *queryBuilder, sortBy string, descending bool) error {
+	operator := ">"
+	if descending {
+		operator = "<"
+	}
+	sortBy = normalizeColumn(sortBy)
+	if sortBy == "" || sortBy == "id" {
+		b.conditions = append(b.conditions, "id "+operator+" "+b.arg(c.ID))
+		return nil
+	}
+	var value any = c.Value
+	if timeColumns[sortBy] {
+		t, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return fmt.Errorf("invalid cursor: %q is not a timestamp", c.Value)
+		}
+		value = t
+	}
+	left := "(" + b.column(sortBy) + ", id)"
+	right := "(" + b.value(value) + ", " + b.arg(c.ID) + ")"
+	b.conditions = append(b.conditions, left+" "+operator+" "+right)
+	return nil
+}
+
+// orderBy returns the ORDER BY clause for a listing sorted by sortBy.
+// Paged listings are always ordered, with ties broken by ID to keep pages stable.
+func (b *queryBuilder) orderBy(sortBy string, descending, paged bool) string {
+	sortBy = normalizeColumn(sortBy)
+	if sortBy == "" && !paged {
+		return ""
+	}
+	direction := ""
+	if descending {
+		direction = " DESC"
+	}
+	if sortBy == "" || sortBy == "id" {
+		return " ORDER BY id" + direction
+	}
+	clause := " ORDER BY " + b.column(sortBy) + direction
+	if paged {
+		clause += ", id" + direction
+	}
+	return clause
+}
+
+// limit returns the LIMIT and OFFSET clauses for a page.
+func (b *queryBuilder) limit(page Page) string {
+	var clause string
+	if page.Limit > 0 {
+		clause += " LIMIT " + strconv.Itoa(page.Limit)
+	} else if page.Offset > 0 && b.dialect == SQLite {
+		// SQLite only accepts OFFSET after LIMIT
+		clause += " LIMIT -1"
+	}
+	if page.Offset > 0 {
+		clause += " OFFSET " + strconv.Itoa(page.Offset)
+	}
+	return clause
+}
+
+// validate checks that the page selects a valid window.
+func (p Page) validate() error {
+	if p.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+	if p.Offset < 0 {
+		return fmt.Errorf("offset cannot be negative")
+	}
+	return nil
+}
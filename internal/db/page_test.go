@@ -0,0 +1,138 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestCursorRoundTrip tests that cursors survive encoding and invalid tokens are rejected
+func TestCursorRoundTrip(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 30, 0, 0, time.FixedZone("CET", 60*60))
+	app := JobApplication{ID: 7, Company: "Google, Inc.", Status: "Applied", CreatedAt: created}
+
+	tests := []struct {
+		sortBy string
+		want   Cursor
+	}{
+		{"", Cursor{ID: 7}},
+		{"id", Cursor{ID: 7}},
+		{"Company", Cursor{Value: "Google, Inc.", ID: 7}},
+		{"created_at", Cursor{Value: "2026-01-15T09:30:00Z", ID: 7}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			got := CursorOf(app, tt.sortBy)
+			if got != tt.want {
+				t.Fatalf("CursorOf() = %+v, want %+v", got, tt.want)
+			}
+			parsed, err := ParseCursor(got.String())
+			if err != nil || parsed != got {
+				t.Errorf("ParseCursor(String()) = %+v, %v, want %+v", parsed, err, got)
+			}
+		})
+	}
+
+	for _, token := range []string{"", "not a cursor", Cursor{}.String()} {
+		if _, err := ParseCursor(token); err == nil {
+			t.Errorf("ParseCursor(%q) should return error", token)
+		}
+	}
+}
+
+// TestPageClauses tests the keyset condition, ORDER BY and LIMIT clauses of paged queries
+func TestPageClauses(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		dialect    Dialect
+		sortBy     string
+		descending bool
+		page       Page
+		wantWhere  string
+		wantArgs   []any
+		wantOrder  string
+		wantLimit  string
+	}{
+		{
+			name:      "unpaged without sorting",
+			wantOrder: "",
+		},
+		{
+			name:      "limit and offset",
+			page:      Page{Limit: 20, Offset: 40},
+			wantOrder: " ORDER BY id",
+			wantLimit: " LIMIT 20 OFFSET 40",
+		},
+		{
+			name:      "offset only on SQLite",
+			dialect:   SQLite,
+			page:      Page{Offset: 5},
+			wantOrder: " ORDER BY id",
+			wantLimit: " LIMIT -1 OFFSET 5",
+		},
+		{
+			name:       "keyset on id",
+			descending: true,
+			page:       Page{Limit: 10, After: &Cursor{ID: 42}},
+			wantWhere:  " WHERE id < $1",
+			wantArgs:   []any{42},
+			wantOrder:  " ORDER BY id DESC",
+			wantLimit:  " LIMIT 10",
+		},
+		{
+			name:      "keyset on sort column",
+			sortBy:    "company",
+			page:      Page{Limit: 10, After: &Cursor{Value: "Google", ID: 3}},
+			wantWhere: " WHERE (company, id) > ($1, $2)",
+			wantArgs:  []any{"Google", 3},
+			wantOrder: " ORDER BY company, id",
+			wantLimit: " LIMIT 10",
+		},
+		{
+			name:      "keyset on timestamp on SQLite",
+			dialect:   SQLite,
+			sortBy:    "created_at",
+			page:      Page{Limit: 10, After: &Cursor{Value: "2026-01-15T10:30:00Z", ID: 3}},
+			wantWhere: " WHERE (datetime(created_at), id) > (datetime($1), $2)",
+			wantArgs:  []any{created, 3},
+			wantOrder: " ORDER BY datetime(created_at), id",
+			wantLimit: " LIMIT 10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := queryBuilder{dialect: tt.dialect}
+			if tt.page.After != nil {
+				if err := tt.page.After.build(&b, tt.sortBy, tt.descending); err != nil {
+					t.Fatalf("build() error = %v", err)
+				}
+			}
+			if got := b.where(); got != tt.wantWhere {
+				t.Errorf("where() = %q, want %q", got, tt.wantWhere)
+			}
+			if !reflect.DeepEqual(b.args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", b.args, tt.wantArgs)
+			}
+			if got := b.orderBy(tt.sortBy, tt.descending, !tt.page.IsZero()); got != tt.wantOrder {
+				t.Errorf("orderBy() = %q, want %q", got, tt.wantOrder)
+			}
+			if got := b.limit(tt.page); got != tt.wantLimit {
+				t.Errorf("limit() = %q, want %q", got, tt.wantLimit)
+			}
+		})
+	}
+
+	var b queryBuilder
+	if err := (Cursor{Value: "yesterday", ID: 1}).build(&b, "updated_at", false); err == nil {
+		t.Error("build() with a non-timestamp value for a timestamp column should return error")
+	}
+	if err := (Page{Limit: -1}).validate(); err == nil {
+		t.Error("validate() with negative limit should return error")
+	}
+}
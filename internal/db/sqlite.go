@@ -0,0 +1,58 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"context"
+	"database/sql"
+)
+
+// SQLite-backed implementation of Store.
+// Portable queries are shared with JobApplicationsStore, dialect-specific ones are overridden.
+type SQLiteStore struct {
+	*JobApplicationsStore
+}
+
+// Constructor for SQLiteStore.
+func NewSQLiteStore(db *sql.DB) *SQLiteStore {
+	store := NewJobApplicationStore(db)
+	store.dialect = SQLite
+	return &SQLiteStore{JobApplicationsStore: store}
+}
+
+// Update updates fields of a job application. Only provided fields are updated.
+// Status changes must follow the workflow transitions unless force is set.
+func (s *SQLiteStore) Update(ctx context.Context, id int, fields map[string]string, force bool) (int64, error) {
+	// SQLite locks the whole database on write, so no row lock is needed
+	return s.update(ctx, id, fields, force, `SELECT status FROM applications WHERE id=$1`)
+}
+
+// UpdateApplication updates fields of a job application, then attaches and detaches tags,
+// all in a single transaction. Status changes must follow the workflow transitions unless force is set,
+// a rejected change being reported as a *TransitionError. Returns ErrNotFound if the application does not exist.
+func (s *SQLiteStore) UpdateApplication(ctx context.Context, id int, fields map[string]string, addTags, removeTags []string, force bool) error {
+	// SQLite locks the whole database on write, so no row lock is needed
+	return s.updateApplication(ctx, id, fields, addTags, removeTags, force, `SELECT status FROM applications WHERE id=$1`)
+}
+
+// Clear clears all job applications (along with their dependent records).
+// IDs start over from 1 once the table is empty.
+func (s *SQLiteStore) Clear(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM applications`)
+	return err
+}
+
+// SyncIDSequence is a no-op: SQLite always assigns the next ID after the largest one.
+func (s *SQLiteStore) SyncIDSequence(ctx context.Context) error {
+	return nil
+}
+
+// Search searches for job applications matching the given keyword in company, position, status, or notes.
+func (s *SQLiteStore) Search(ctx context.Context, keyword string) ([]JobApplication, error) {
+	// LIKE is case-insensitive for ASCII characters in SQLite
+	query := `SELECT id, company, position, status, created_at, updated_at FROM applications
+		WHERE company LIKE $1 OR position LIKE $1 OR status LIKE $1
+		OR EXISTS (SELECT 1 FROM notes WHERE notes.application_id = applications.id AND notes.content LIKE $1)`
+	return s.queryApplications(ctx, query, "%"+keyword+"%")
+}
@@ -0,0 +1,485 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/dbtest"
+)
+
+func TestSQLiteStoreCRUD(t *testing.T) {
+	store := dbtest.NewSQLiteStore(t)
+	ctx := context.Background()
+
+	if _, ok := store.(*db.SQLiteStore); !ok {
+		t.Fatalf("NewStore() returned %T, want *db.SQLiteStore", store)
+	}
+
+	if _, err := store.Add(ctx, "Google", "Software Engineer", "applied", nil, false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := store.Add(ctx, "Apple", "Data Scientist", "Applied", nil, false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	rows, err := store.Read(ctx, "company", false, db.Filter{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0].Company != "Apple" || rows[1].Company != "Google" {
+		t.Fatalf("Read() = %+v, want Apple and Google sorted by company", rows)
+	}
+	if rows[1].Status != "Applied" {
+		t.Errorf("Add() stored status %q, want normalized %q", rows[1].Status, "Applied")
+	}
+	if rows[0].CreatedAt.IsZero() {
+		t.Error("Read() returned zero CreatedAt")
+	}
+
+	found, err := store.Search(ctx, "engineer")
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+	if len(found) != 1 || found[0].Company != "Google" {
+		t.Errorf("Search() = %+v, want Google only", found)
+	}
+
+	affected, err := store.Update(ctx, rows[1].ID, map[string]string{"status": "Screening", "position": "SRE"}, false)
+	if err != nil || affected != 1 {
+		t.Fatalf("Update() = %d, %v, want 1, nil", affected, err)
+	}
+	if _, err := store.Update(ctx, rows[1].ID, map[string]string{"status": "Applied"}, false); err == nil {
+		t.Error("Update() with illegal transition should return error")
+	}
+	if affected, err := store.Update(ctx, 999, map[string]string{"status": "Screening"}, false); err != nil || affected != 0 {
+		t.Errorf("Update() of missing ID = %d, %v, want 0, nil", affected, err)
+	}
+
+	app, err := store.Get(ctx, rows[1].ID)
+	if err != nil || app.Company != "Google" || app.Position != "SRE" || app.Status != "Screening" {
+		t.Errorf("Get() = %+v, %v, want updated Google application", app, err)
+	}
+	if _, err := store.Get(ctx, 999); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("Get() of missing ID error = %v, want ErrNotFound", err)
+	}
+
+	history, err := store.History(ctx, rows[1].ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(history) != 2 || history[0].ToStatus != "Applied" || history[1].FromStatus != "Applied" || history[1].ToStatus != "Screening" {
+		t.Errorf("History() = %+v, want Applied -> Screening", history)
+	}
+
+	if affected, err := store.Delete(ctx, rows[1].ID); err != nil || affected != 1 {
+		t.Fatalf("Delete() = %d, %v, want 1, nil", affected, err)
+	}
+	if history, err := store.History(ctx, rows[1].ID); err != nil || len(history) != 0 {
+		t.Errorf("History() after Delete() = %+v, %v, want cascade delete", history, err)
+	}
+
+	if err := store.Clear(ctx); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if all, err := store.ReadHistory(ctx); err != nil || len(all) != 0 {
+		t.Errorf("ReadHistory() after Clear() = %+v, %v, want empty", all, err)
+	}
+	if _, err := store.Add(ctx, "Tesla", "ML Engineer", "Applied", nil, false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if rows, _ := store.Read(ctx, "", false, db.Filter{}); len(rows) != 1 || rows[0].ID != 1 {
+		t.Errorf("Read() after Clear() = %+v, want ID counter reset to 1", rows)
+	}
+}
+
+func TestSQLiteStoreInsert(t *testing.T) {
+	store := dbtest.NewSQLiteStore(t)
+	ctx := context.Background()
+
+	created := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	app := db.JobApplication{ID: 42, Company: "Google", Position: "Engineer", Status: "Interview", CreatedAt: created, UpdatedAt: created}
+	id, err := store.Insert(ctx, app, db.ImportOptions{KeepIDs: true, KeepTimestamps: true})
+	if err != nil || id != 42 {
+		t.Fatalf("Insert() = %d, %v, want 42, nil", id, err)
+	}
+	if err := store.SyncIDSequence(ctx); err != nil {
+		t.Fatalf("SyncIDSequence() error = %v", err)
+	}
+	if _, err := store.Add(ctx, "Apple", "Engineer", "Applied", nil, false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	rows, err := store.Read(ctx, "id", false, db.Filter{})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0].ID != 42 || rows[1].ID != 43 {
+		t.Fatalf("Read() = %+v, want IDs 42 and 43", rows)
+	}
+	if !rows[0].CreatedAt.Equal(created) {
+		t.Errorf("Insert() kept CreatedAt %v, want %v", rows[0].CreatedAt, created)
+	}
+
+	if _, err := store.Insert(ctx, app, db.ImportOptions{KeepIDs: true}); err == nil {
+		t.Error("Insert() with duplicate ID should return error")
+	}
+	if _, err := store.Insert(ctx, db.JobApplication{Company: "X", Position: "Y", Status: "Ghosted"}, db.ImportOptions{}); err == nil {
+		t.Error("Insert() with unknown status should return error")
+	}
+}
+
+func TestSQLiteStoreNotes(t *testing.T) {
+	store := dbtest.NewSQLiteStore(t)
+	ctx := context.Background()
+
+	if _, err := store.Add(ctx, "Google", "Software Engineer", "Applied", nil, false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if id, err := store.AddNote(ctx, 999, "Orphan"); err != nil || id != 0 {
+		t.Errorf("AddNote() to missing application = %d, %v, want 0, nil", id, err)
+	}
+	if _, err := store.AddNote(ctx, 1, "   "); err == nil {
+		t.Error("AddNote() with empty content should return error")
+	}
+	first, err := store.AddNote(ctx, 1, "Recruiter: Jane Doe")
+	if err != nil || first == 0 {
+		t.Fatalf("AddNote() = %d, %v", first, err)
+	}
+	second, err := store.AddNote(ctx, 1, "Follow up after Tuesday")
+	if err != nil {
+		t.Fatalf("AddNote() error = %v", err)
+	}
+
+	found, err := store.Search(ctx, "jane")
+	if err != nil || len(found) != 1 {
+		t.Errorf("Search() by note content = %+v, %v, want one application", found, err)
+	}
+
+	if affected, err := store.UpdateNote(ctx, second, "Follow up after Wednesday"); err != nil || affected != 1 {
+		t.Errorf("UpdateNote() = %d, %v, want 1, nil", affected, err)
+	}
+	if affected, err := store.DeleteNote(ctx, first); err != nil || affected != 1 {
+		t.Errorf("DeleteNote() = %d, %v, want 1, nil", affected, err)
+	}
+	notes, err := store.ReadNotes(ctx, 1)
+	if err != nil {
+		t.Fatalf("ReadNotes() error = %v", err)
+	}
+	if len(notes) != 1 || notes[0].Content != "Follow up after Wednesday" {
+		t.Errorf("ReadNotes() = %+v, want the edited note only", notes)
+	}
+
+	if _, err := store.Delete(ctx, 1); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if notes, err := store.ReadAllNotes(ctx); err != nil || len(notes) != 0 {
+		t.Errorf("ReadAllNotes() after Delete() = %+v, %v, want cascade delete", notes, err)
+	}
+
+	// Notes are restored along with imported applications
+	app := db.JobApplication{Company: "Apple", Position: "Engineer", Status: "Applied", Notes: []db.Note{{Content: "Imported"}}}
+	id, err := store.Insert(ctx, app, db.ImportOptions{})
+	if err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if notes, err := store.ReadNotes(ctx, id); err != nil || len(notes) != 1 || notes[0].Content != "Imported" {
+		t.Errorf("ReadNotes() after Insert() = %+v, %v, want imported note", notes, err)
+	}
+}
+
+func TestSQLiteStoreTags(t *testing.T) {
+	store := dbtest.NewSQLiteStore(t)
+	ctx := context.Background()
+
+	google, err := store.Add(ctx, "Google", "Engineer", "Applied", []string{"Remote", "backend"}, false)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	apple, err := store.Add(ctx, "Apple", "Engineer", "Applied", []string{"referral"}, false)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := store.Add(ctx, "Tesla", "Engineer", "Applied", nil, false); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if _, err := store.AddTags(ctx, 999, []string{"remote"}); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("AddTags() to missing application error = %v, want ErrNotFound", err)
+	}
+	if added, err := store.AddTags(ctx, apple, []string{"remote", "REMOTE"}); err != nil || added != 1 {
+		t.Errorf("AddTags() = %d, %v, want 1, nil", added, err)
+	}
+
+	all, err := store.Read(ctx, "id", false, db.Filter{Tags: []string{"remote", "backend"}})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(all) != 1 || all[0].ID != google || len(all[0].Tags) != 2 {
+		t.Errorf("Read() with all tags = %+v, want Google with two tags", all)
+	}
+	anyTag, err := store.Read(ctx, "id", false, db.Filter{Tags: []string{"backend", "referral"}, AnyTag: true})
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if len(anyTag) != 2 || anyTag[0].ID != google || anyTag[1].ID != apple {
+		t.Errorf("Read() with any tag = %+v, want Google and Apple", anyTag)
+	}
+
+	if removed, err := store.RemoveTags(ctx, google, []string{"backend"}); err != nil || removed != 1 {
+		t.Errorf("RemoveTags() = %d, %v, want 1, nil", removed, err)
+	}
+	if renamed, err := store.RenameTag(ctx, "referral", "remote"); err != nil || renamed != 1 {
+		t.Errorf("RenameTag() merge = %d, %v, want 1, nil", renamed, err)
+	}
+	if renamed, err := store.RenameTag(ctx, "missing", "other"); err != nil || renamed != 0 {
+		t.Errorf("RenameTag() of missing tag = %d, %v, want 0, nil", renamed, err)
+	}
+
+	tags, err := store.ReadTags(ctx)
+	if err != nil {
+		t.Fatalf("ReadTags() error = %v", err)
+	}
+	want := []db.Tag{{Name: "backend", Applications: 0}, {Name: "remote", Applications: 2}}
+	if len(tags) != len(want) {
+		t.Fatalf("ReadTags() = %+v, want %+v", tags, want)
+	}
+	for i := range want {
+		if tags[i].Name != want[i].Name || tags[i].Applications != want[i].Applications {
+			t.Errorf("ReadTags()[%d] = %+v, want %+v", i, tags[i], want[i])
+		}
+	}
+
+	if deleted, err := store.DeleteTag(ctx, "remote"); err != nil || deleted != 1 {
+		t.Errorf("DeleteTag() = %d, %v, want 1, nil", deleted, err)
+	}
+	if rows, _ := store.Read(ctx, "", false, db.Filter{Tags: []string{"remote"}}); len(rows) != 0 {
+		t.Errorf("Read() after DeleteTag() = %+v, want no applications", rows)
+	}
+
+	// Tags are restored along with imported applications
+	id, err := store.Insert(ctx, db.JobApplication{Company: "Meta", Position: "Engineer", Status: "Applied", Tags: []string{"dream-company"}}, db.ImportOptions{})
+	if err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	if rows, _ := store.Read(ctx, "", false, db.Filter{Tags: []string{"dream-company"}}); len(rows) != 1 || rows[0].ID != id {
+		t.Errorf("Read() after Insert() = %+v, want imported application", rows)
+	}
+}
+
+func TestSQLiteStoreUpdateApplication(t *testing.T) {
+	store := dbtest.NewSQLiteStore(t, db.JobApplication{Company: "Google", Position: "Engineer", Tags: []string{"remote"}})
+	ctx := context.Background()
+
+	fields := map[string]string{"position": "Staff Engineer", "status": "Interview"}
+	if err := store.UpdateApplication(ctx, 1, fields, []string{"Urgent"}, []string{"remote"}, false); err != nil {
+		t.Fatalf("UpdateApplication() error = %v", err)
+	}
+	app, _ := store.Get(ctx, 1)
+	if app.Position != "Staff Engineer" || app.Status != "Interview" || !reflect.DeepEqual(app.Tags, []string{"urgent"}) {
+		t.Errorf("Get() after UpdateApplication() = %+v", app)
+	}
+
+	// A rejected transition leaves the tags untouched as well
+	var transitionErr *db.TransitionError
+	err := store.UpdateApplication(ctx, 1, map[string]string{"status": "Applied"}, []string{"onsite"}, []string{"urgent"}, false)
+	if !errors.As(err, &transitionErr) {
+		t.Errorf("UpdateApplication() with invalid transition error = %v, want *TransitionError", err)
+	}
+	if app, _ := store.Get(ctx, 1); app.Status != "Interview" || !reflect.DeepEqual(app.Tags, []string{"urgent"}) {
+		t.Errorf("Get() after rejected UpdateApplication() = %+v, want it unchanged", app)
+	}
+
+	if err := store.UpdateApplication(ctx, 99, nil, []string{"onsite"}, nil, false); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("UpdateApplication() of missing application error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLiteStoreFilter(t *testing.T) {
+	store := dbtest.NewSQLiteStore(t)
+	ctx := context.Background()
+
+	// Timestamps with different offsets are compared as instants
+	jan := time.Date(2026, 1, 10, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+	mar := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	apps := []db.JobApplication{
+		{Company: "Google", Position: "Engineer", Status: "Applied", CreatedAt: jan, UpdatedAt: jan},
+		{Company: "Meta", Position: "Engineer", Status: "Interview", CreatedAt: mar, UpdatedAt: mar},
+		{Company: "Googleplex", Position: "Manager", Status: "Interview"},
+	}
+	for _, app := range apps {
+		if _, err := store.Insert(ctx, app, db.ImportOptions{KeepTimestamps: true}); err != nil {
+			t.Fatalf("Insert() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		filter  db.Filter
+		wantIDs []int
+	}{
+		{"no filter", db.Filter{}, []int{1, 2, 3}},
+		{"status", db.Filter{Statuses: []string{"interview"}}, []int{2, 3}},
+		{"company", db.Filter{Company: "GOOGLE"}, []int{1, 3}},
+		{"combined", db.Filter{Statuses: []string{"Interview"}, Company: "google"}, []int{3}},
+		{"since across offsets", db.Filter{Since: time.Date(2026, 1, 11, 4, 0, 0, 0, time.UTC)}, []int{1, 2, 3}},
+		{"until", db.Filter{Until: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}, []int{1}},
+		{"updated since", db.Filter{UpdatedSince: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)}, []int{2, 3}},
+		{"stale", db.Filter{Stale: 24 * time.Hour}, []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := store.Read(ctx, "id", false, tt.filter)
+			if err != nil {
+				t.Fatalf("Read() error = %v", err)
+			}
+			var ids []int
+			for _, row := range rows {
+				ids = append(ids, row.ID)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("Read() IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	deleted, err := store.DeleteMatching(ctx, db.Filter{Stale: 24 * time.Hour})
+	if err != nil || deleted != 2 {
+		t.Fatalf("DeleteMatching() = %d, %v, want 2, nil", deleted, err)
+	}
+	if rows, _ := store.Read(ctx, "", false, db.Filter{}); len(rows) != 1 || rows[0].Company != "Googleplex" {
+		t.Errorf("Read() after DeleteMatching() = %+v, want Googleplex only", rows)
+	}
+}
+
+func TestSQLiteStoreReadPage(t *testing.T) {
+	store := dbtest.NewSQLiteStore(t)
+	ctx := context.Background()
+
+	for _, company := range []string{"Meta", "Apple", "Google", "Apple", "Meta"} {
+		if _, err := store.Add(ctx, company, "Engineer", "Applied", nil, false); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if total, err := store.Count(ctx, db.Filter{Company: "apple"}); err != nil || total != 2 {
+		t.Errorf("Count() = %d, %v, want 2, nil", total, err)
+	}
+
+	// Walking the listing sorted by company with keyset pagination
+	var ids []int
+	page := db.Page{Limit: 2}
+	for {
+		rows, more, err := store.ReadPage(ctx, "company", false, db.Filter{}, page)
+		if err != nil {
+			t.Fatalf("ReadPage() error = %v", err)
+		}
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		if !more {
+			break
+		}
+		cursor := db.CursorOf(rows[len(rows)-1], "company")
+		page.After = &cursor
+	}
+	if want := []int{2, 4, 3, 1, 5}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("keyset pages IDs = %v, want %v", ids, want)
+	}
+
+	rows, more, err := store.ReadPage(ctx, "id", true, db.Filter{}, db.Page{Limit: 2, Offset: 1})
+	if err != nil {
+		t.Fatalf("ReadPage() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0].ID != 4 || rows[1].ID != 3 || !more {
+		t.Errorf("ReadPage() with offset = %+v, %v, want IDs 4 and 3 and more", rows, more)
+	}
+
+	// An exactly full last page has no more results
+	rows, more, err = store.ReadPage(ctx, "id", true, db.Filter{}, db.Page{Limit: 2, Offset: 3})
+	if err != nil {
+		t.Fatalf("ReadPage() error = %v", err)
+	}
+	if len(rows) != 2 || more {
+		t.Errorf("ReadPage() of the last page = %+v, %v, want 2 rows and no more", rows, more)
+	}
+	cursor := db.CursorOf(rows[0], "id")
+	if rows, more, err = store.ReadPage(ctx, "id", true, db.Filter{}, db.Page{Limit: 1, After: &cursor}); err != nil || len(rows) != 1 || more {
+		t.Errorf("ReadPage() of the last keyset page = %+v, %v, %v, want 1 row and no more", rows, more, err)
+	}
+}
+
+func TestSQLiteStoreFollowUps(t *testing.T) {
+	store := dbtest.NewSQLiteStore(t)
+	ctx := context.Background()
+
+	google, err := store.Add(ctx, "Google", "Engineer", "Applied", nil, false)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	apple, err := store.Add(ctx, "Apple", "Engineer", "Applied", nil, false)
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	if err := store.ScheduleFollowUp(ctx, 999, time.Now()); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("ScheduleFollowUp() of missing application error = %v, want ErrNotFound", err)
+	}
+	if err := store.CompleteFollowUp(ctx, 999); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("CompleteFollowUp() of missing application error = %v, want ErrNotFound", err)
+	}
+
+	// Scheduling again replaces the pending follow-up
+	due := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	if err := store.ScheduleFollowUp(ctx, google, due.Add(-48*time.Hour)); err != nil {
+		t.Fatalf("ScheduleFollowUp() error = %v", err)
+	}
+	if err := store.ScheduleFollowUp(ctx, google, due); err != nil {
+		t.Fatalf("ScheduleFollowUp() error = %v", err)
+	}
+	// Completing a follow-up derived from a rule records when it was done
+	before := time.Now().Add(-time.Second)
+	if err := store.CompleteFollowUp(ctx, apple); err != nil {
+		t.Fatalf("CompleteFollowUp() error = %v", err)
+	}
+
+	states, err := store.ReadFollowUps(ctx)
+	if err != nil {
+		t.Fatalf("ReadFollowUps() error = %v", err)
+	}
+	if len(states) != 2 {
+		t.Fatalf("ReadFollowUps() = %+v, want two applications", states)
+	}
+	if states[0].ApplicationID != google || !states[0].DueAt.Equal(due) || !states[0].LastDone.IsZero() {
+		t.Errorf("ReadFollowUps()[0] = %+v, want Google due at %v", states[0], due)
+	}
+	if states[1].ApplicationID != apple || !states[1].DueAt.IsZero() || states[1].LastDone.Before(before) {
+		t.Errorf("ReadFollowUps()[1] = %+v, want Apple done just now", states[1])
+	}
+
+	if err := store.CompleteFollowUp(ctx, google); err != nil {
+		t.Fatalf("CompleteFollowUp() error = %v", err)
+	}
+	states, err = store.ReadFollowUps(ctx)
+	if err != nil {
+		t.Fatalf("ReadFollowUps() error = %v", err)
+	}
+	if !states[0].DueAt.IsZero() || states[0].LastDone.Before(before) {
+		t.Errorf("ReadFollowUps()[0] after CompleteFollowUp() = %+v, want no pending follow-up", states[0])
+	}
+
+	// Follow-ups are deleted along with their application
+	if _, err := store.Delete(ctx, google); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if states, _ := store.ReadFollowUps(ctx); len(states) != 1 || states[0].ApplicationID != apple {
+		t.Errorf("ReadFollowUps() after Delete() = %+v, want only Apple", states)
+	}
+}
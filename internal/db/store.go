@@ -0,0 +1,58 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Store defines the operations on job applications shared by all database backends.
+type Store interface {
+	// Workflow returns the status workflow used to validate status changes.
+	Workflow() *Workflow
+	// SetWorkflow replaces the status workflow used to validate status changes.
+	SetWorkflow(w *Workflow)
+
+	Add(ctx context.Context, company, position, status string, tags []string, force bool) (int, error)
+	Insert(ctx context.Context, app JobApplication, opts ImportOptions) (int, error)
+	SyncIDSequence(ctx context.Context) error
+	Read(ctx context.Context, sortBy string, descending bool, filter Filter) ([]JobApplication, error)
+	ReadPage(ctx context.Context, sortBy string, descending bool, filter Filter, page Page) ([]JobApplication, bool, error)
+	Count(ctx context.Context, filter Filter) (int, error)
+	Get(ctx context.Context, id int) (JobApplication, error)
+	Update(ctx context.Context, id int, fields map[string]string, force bool) (int64, error)
+	UpdateApplication(ctx context.Context, id int, fields map[string]string, addTags, removeTags []string, force bool) error
+	Delete(ctx context.Context, id int) (int64, error)
+	Clear(ctx context.Context) error
+	DeleteMatching(ctx context.Context, filter Filter) (int64, error)
+	Search(ctx context.Context, keyword string) ([]JobApplication, error)
+	History(ctx context.Context, id int) ([]StatusChange, error)
+	ReadHistory(ctx context.Context) ([]StatusChange, error)
+
+	AddNote(ctx context.Context, applicationID int, content string) (int, error)
+	ReadNotes(ctx context.Context, applicationID int) ([]Note, error)
+	ReadAllNotes(ctx context.Context) ([]Note, error)
+	UpdateNote(ctx context.Context, id int, content string) (int64, error)
+	DeleteNote(ctx context.Context, id int) (int64, error)
+
+	AddTags(ctx context.Context, applicationID int, names []string) (int64, error)
+	RemoveTags(ctx context.Context, applicationID int, names []string) (int64, error)
+	DeleteTag(ctx context.Context, name string) (int64, error)
+	RenameTag(ctx context.Context, from, to string) (int64, error)
+	ReadTags(ctx context.Context) ([]Tag, error)
+
+	ScheduleFollowUp(ctx context.Context, applicationID int, dueAt time.Time) error
+	CompleteFollowUp(ctx context.Context, applicationID int) error
+	ReadFollowUps(ctx context.Context) ([]FollowUpState, error)
+}
+
+// NewStore returns the Store implementation matching the dialect of the connection.
+func NewStore(db *sql.DB) Store {
+	if DialectOf(db) == SQLite {
+		return NewSQLiteStore(db)
+	}
+	return NewJobApplicationStore(db)
+}
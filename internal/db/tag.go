@@ -0,0 +1,270 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned when an operation refers to a job application that does not exist.
+var ErrNotFound = errors.New("job application not found")
+
+// maxTagLength is the maximum length of a tag name
+const maxTagLength = 64
+
+// NormalizeTag trims and lowercases a tag name and checks that it is valid.
+func NormalizeTag(name string) (string, error) {
+	tag := strings.ToLower(strings.TrimSpace(name))
+	if tag == "" {
+		return "", fmt.Errorf("tag cannot be empty")
+	}
+	if len(tag) > maxTagLength {
+		return "", fmt.Errorf("tag %q is too long (maximum %d characters)", name, maxTagLength)
+	}
+	if strings.ContainsAny(tag, ", \t\n") {
+		return "", fmt.Errorf("invalid tag: %q (tags cannot contain commas or whitespace)", name)
+	}
+	return tag, nil
+}
+
+// NormalizeTags normalizes tag names, dropping duplicates.
+func NormalizeTags(names []string) ([]string, error) {
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tag, err := NormalizeTag(name)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
+// AddTags attaches tags to a job application, creating missing tags.
+// Returns the number of tags newly attached, or ErrNotFound if the application does not exist.
+func (s *JobApplicationsStore) AddTags(ctx context.Context, applicationID int, names []string) (int64, error) {
+	tags, err := NormalizeTags(names)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := checkApplicationExists(ctx, tx, applicationID); err != nil {
+		return 0, err
+	}
+	added, err := insertTags(ctx, tx, applicationID, tags)
+	if err != nil {
+		return 0, err
+	}
+	return added, tx.Commit()
+}
+
+// RemoveTags detaches tags from a job application.
+// Returns the number of tags detached, or ErrNotFound if the application does not exist.
+func (s *JobApplicationsStore) RemoveTags(ctx context.Context, applicationID int, names []string) (int64, error) {
+	tags, err := NormalizeTags(names)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := checkApplicationExists(ctx, tx, applicationID); err != nil {
+		return 0, err
+	}
+	removed, err := deleteTags(ctx, tx, applicationID, tags)
+	if err != nil {
+		return 0, err
+	}
+	return removed, tx.Commit()
+}
+
+// DeleteTag deletes a tag and detaches it from all job applications.
+func (s *JobApplicationsStore) DeleteTag(ctx context.Context, name string) (int64, error) {
+	tag, err := NormalizeTag(name)
+	if err != nil {
+		return 0, err
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM tags WHERE name=$1`, tag)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// RenameTag renames a tag. If a tag with the new name already exists, both tags are merged.
+// Returns 0 if there is no tag with the old name.
+func (s *JobApplicationsStore) RenameTag(ctx context.Context, from, to string) (int64, error) {
+	oldName, err := NormalizeTag(from)
+	if err != nil {
+		return 0, err
+	}
+	newName, err := NormalizeTag(to)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var oldID, newID int
+	err = tx.QueryRowContext(ctx, `SELECT id FROM tags WHERE name=$1`, oldName).Scan(&oldID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	err = tx.QueryRowContext(ctx, `SELECT id FROM tags WHERE name=$1`, newName).Scan(&newID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		if _, err := tx.ExecContext(ctx, `UPDATE tags SET name=$1 WHERE id=$2`, newName, oldID); err != nil {
+			return 0, err
+		}
+	case err != nil:
+		return 0, err
+	case newID != oldID:
+		// Merging into the existing tag
+		query := `INSERT INTO application_tags (application_id, tag_id)
+			SELECT application_id, $1 FROM application_tags WHERE tag_id=$2
+			ON CONFLICT DO NOTHING`
+		if _, err := tx.ExecContext(ctx, query, newID, oldID); err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM tags WHERE id=$1`, oldID); err != nil {
+			return 0, err
+		}
+	}
+	return 1, tx.Commit()
+}
+
+// ReadTags retrieves all tags with the number of applications carrying them.
+func (s *JobApplicationsStore) ReadTags(ctx context.Context) ([]Tag, error) {
+	query := `SELECT tags.id, tags.name, COUNT(application_tags.application_id)
+		FROM tags LEFT JOIN application_tags ON application_tags.tag_id = tags.id
+		GROUP BY tags.id, tags.name ORDER BY tags.name`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []Tag
+	for rows.Next() {
+		var t Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.Applications); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// attachTags loads the tags of the given job applications.
+func (s *JobApplicationsStore) attachTags(ctx context.Context, apps []JobApplication) error {
+	if len(apps) == 0 {
+		return nil
+	}
+	b := queryBuilder{dialect: s.dialect}
+	index := make(map[int]int, len(apps))
+	placeholders := make([]string, len(apps))
+	for i, app := range apps {
+		index[app.ID] = i
+		placeholders[i] = b.arg(app.ID)
+	}
+	query := `SELECT application_tags.application_id, tags.name
+		FROM application_tags JOIN tags ON tags.id = application_tags.tag_id
+		WHERE application_tags.application_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY tags.name`
+	rows, err := s.db.QueryContext(ctx, query, b.args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return err
+		}
+		if i, ok := index[id]; ok {
+			apps[i].Tags = append(apps[i].Tags, name)
+		}
+	}
+	return rows.Err()
+}
+
+// checkApplicationExists returns ErrNotFound if there is no job application with the given ID.
+func checkApplicationExists(ctx context.Context, tx *sql.Tx, id int) error {
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM applications WHERE id=$1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// insertTags attaches normalized tags to a job application within a transaction,
+// creating missing tags. Returns the number of tags newly attached.
+func insertTags(ctx context.Context, tx *sql.Tx, applicationID int, tags []string) (int64, error) {
+	var added int64
+	for _, tag := range tags {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO tags (name) VALUES ($1) ON CONFLICT (name) DO NOTHING`, tag); err != nil {
+			return 0, err
+		}
+		query := `INSERT INTO application_tags (application_id, tag_id)
+			SELECT $1, id FROM tags WHERE name=$2
+			ON CONFLICT DO NOTHING`
+		res, err := tx.ExecContext(ctx, query, applicationID, tag)
+		if err != nil {
+			return 0, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		added += n
+	}
+	return added, nil
+}
+
+// deleteTags detaches normalized tags from a job application within a transaction.
+// Returns the number of tags detached.
+func deleteTags(ctx context.Context, tx *sql.Tx, applicationID int, tags []string) (int64, error) {
+	var removed int64
+	query := `DELETE FROM application_tags
+		WHERE application_id=$1 AND tag_id IN (SELECT id FROM tags WHERE name=$2)`
+	for _, tag := range tags {
+		res, err := tx.ExecContext(ctx, query, applicationID, tag)
+		if err != nil {
+			return 0, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		removed += n
+	}
+	return removed, nil
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestNormalizeTag tests that tag names are trimmed, lowercased and validated
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"lowercase", "remote", "remote", false},
+		{"mixed case and spaces", "  Dream-Company ", "dream-company", false},
+		{"empty", "   ", "", true},
+		{"inner whitespace", "dream company", "", true},
+		{"comma", "remote,backend", "", true},
+		{"too long", strings.Repeat("a", maxTagLength+1), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTag(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeTag(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeTag(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestNormalizeTags tests that duplicate tags are dropped while keeping order
+func TestNormalizeTags(t *testing.T) {
+	got, err := NormalizeTags([]string{"Remote", "backend", "remote", " BACKEND "})
+	if err != nil {
+		t.Fatalf("NormalizeTags() error = %v", err)
+	}
+	if want := []string{"remote", "backend"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeTags() = %v, want %v", got, want)
+	}
+}
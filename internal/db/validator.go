@@ -18,11 +18,22 @@ var validColumns = map[string]bool{
 	"updated_at": true,
 }
 
+// timeColumns defines the timestamp columns of the applications table
+var timeColumns = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+}
+
+// normalizeColumn normalizes a column name for lookups.
+func normalizeColumn(column string) string {
+	return strings.ToLower(strings.TrimSpace(column))
+}
+
 // ValidateColumnName checks if a column name is valid for SQL operations.
 // It prevents SQL injection by ensuring only whitelisted columns are used.
 func ValidateColumnName(column string) error {
 	// Normalize to lowercase for case-insensitive comparison
-	normalized := strings.ToLower(strings.TrimSpace(column))
+	normalized := normalizeColumn(column)
 
 	// Check for empty string after trimming
 	if normalized == "" {
@@ -0,0 +1,153 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+)
+
+// DefaultStatuses defines the default order of application statuses.
+var DefaultStatuses = []string{"Applied", "Screening", "Interview", "Offer", "Rejected", "Withdrawn"}
+
+// DefaultTransitions defines the allowed status transitions of the default workflow.
+var DefaultTransitions = map[string][]string{
+	"Applied":   {"Screening", "Interview", "Rejected", "Withdrawn"},
+	"Screening": {"Interview", "Rejected", "Withdrawn"},
+	"Interview": {"Offer", "Rejected", "Withdrawn"},
+	"Offer":     {"Rejected", "Withdrawn"},
+}
+
+// TransitionError reports a status change rejected by the workflow.
+type TransitionError struct {
+	Err error
+}
+
+func (e *TransitionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransitionError) Unwrap() error {
+	return e.Err
+}
+
+// Workflow describes the set of known statuses and the allowed transitions between them.
+type Workflow struct {
+	statuses    []string
+	canonical   map[string]string
+	transitions map[string]map[string]bool
+}
+
+// NewWorkflow builds a workflow from config, falling back to the default one if config is nil.
+func NewWorkflow(cfg *config.WorkflowConfig) (*Workflow, error) {
+	if cfg == nil || len(cfg.Statuses) == 0 {
+		return buildWorkflow(DefaultStatuses, DefaultTransitions)
+	}
+	return buildWorkflow(cfg.Statuses, cfg.Transitions)
+}
+
+// DefaultWorkflow returns the built-in status workflow.
+func DefaultWorkflow() *Workflow {
+	w, _ := buildWorkflow(DefaultStatuses, DefaultTransitions)
+	return w
+}
+
+// buildWorkflow validates statuses and transitions and assembles a workflow.
+func buildWorkflow(statuses []string, transitions map[string][]string) (*Workflow, error) {
+	w := &Workflow{
+		canonical:   make(map[string]string, len(statuses)),
+		transitions: make(map[string]map[string]bool, len(transitions)),
+	}
+	for _, s := range statuses {
+		name := strings.TrimSpace(s)
+		if name == "" {
+			return nil, fmt.Errorf("workflow status cannot be empty")
+		}
+		key := strings.ToLower(name)
+		if _, ok := w.canonical[key]; ok {
+			return nil, fmt.Errorf("duplicate workflow status: %q", name)
+		}
+		w.canonical[key] = name
+		w.statuses = append(w.statuses, name)
+	}
+	for from, targets := range transitions {
+		src, ok := w.canonical[strings.ToLower(strings.TrimSpace(from))]
+		if !ok {
+			return nil, fmt.Errorf("workflow transition from unknown status: %q", from)
+		}
+		allowed := make(map[string]bool, len(targets))
+		for _, to := range targets {
+			dst, ok := w.canonical[strings.ToLower(strings.TrimSpace(to))]
+			if !ok {
+				return nil, fmt.Errorf("workflow transition to unknown status: %q", to)
+			}
+			allowed[dst] = true
+		}
+		w.transitions[src] = allowed
+	}
+	return w, nil
+}
+
+// Statuses returns the workflow statuses in their configured order.
+func (w *Workflow) Statuses() []string {
+	return append([]string(nil), w.statuses...)
+}
+
+// Next returns the statuses reachable from the given status.
+func (w *Workflow) Next(status string) []string {
+	src, ok := w.canonical[strings.ToLower(strings.TrimSpace(status))]
+	if !ok {
+		return nil
+	}
+	var next []string
+	for _, s := range w.statuses {
+		if w.transitions[src][s] {
+			next = append(next, s)
+		}
+	}
+	return next
+}
+
+// IsTerminal reports whether a status of the workflow allows no further transitions, e.g. Rejected.
+// Statuses outside of the workflow are not terminal.
+func (w *Workflow) IsTerminal(status string) bool {
+	src, ok := w.canonical[strings.ToLower(strings.TrimSpace(status))]
+	return ok && len(w.transitions[src]) == 0
+}
+
+// NormalizeStatus returns the canonical spelling of a status, matching case-insensitively.
+func (w *Workflow) NormalizeStatus(status string) (string, error) {
+	name := strings.TrimSpace(status)
+	if name == "" {
+		return "", fmt.Errorf("status cannot be empty")
+	}
+	canonical, ok := w.canonical[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("invalid status: %q (allowed: %s)", status, strings.Join(w.statuses, ", "))
+	}
+	return canonical, nil
+}
+
+// ValidateTransition checks that moving from one status to another is allowed
+// and returns the canonical spelling of the target status.
+func (w *Workflow) ValidateTransition(from, to string) (string, error) {
+	dst, err := w.NormalizeStatus(to)
+	if err != nil {
+		return "", err
+	}
+	src, err := w.NormalizeStatus(from)
+	if err != nil {
+		return "", fmt.Errorf("current status %q is not part of the workflow; use --force to override", from)
+	}
+	if src == dst || w.transitions[src][dst] {
+		return dst, nil
+	}
+	next := w.Next(src)
+	if len(next) == 0 {
+		return "", fmt.Errorf("invalid status transition: %q is a final status; use --force to override", src)
+	}
+	return "", fmt.Errorf("invalid status transition: %q -> %q (allowed: %s); use --force to override", src, dst, strings.Join(next, ", "))
+}
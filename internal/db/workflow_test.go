@@ -0,0 +1,175 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package db
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+)
+
+func TestWorkflowNormalizeStatus(t *testing.T) {
+	w := DefaultWorkflow()
+
+	tests := []struct {
+		name    string
+		status  string
+		want    string
+		wantErr bool
+	}{
+		{"canonical", "Applied", "Applied", false},
+		{"lowercase", "applied", "Applied", false},
+		{"uppercase", "INTERVIEW", "Interview", false},
+		{"trailing space", "Applied ", "Applied", false},
+		{"unknown", "Interviewing", "", true},
+		{"empty", "", "", true},
+		{"only spaces", "   ", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := w.NormalizeStatus(tt.status)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeStatus(%q) error = %v, wantErr %v", tt.status, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeStatus(%q) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflowValidateTransition(t *testing.T) {
+	w := DefaultWorkflow()
+
+	tests := []struct {
+		name    string
+		from    string
+		to      string
+		want    string
+		wantErr bool
+	}{
+		{"applied to screening", "Applied", "Screening", "Screening", false},
+		{"screening to interview", "Screening", "interview", "Interview", false},
+		{"interview to offer", "Interview", "Offer", "Offer", false},
+		{"interview to rejected", "Interview", "Rejected", "Rejected", false},
+		{"offer to withdrawn", "Offer", "Withdrawn", "Withdrawn", false},
+		{"same status", "Interview", "interview", "Interview", false},
+		{"legacy spelling of current status", "applied ", "Screening", "Screening", false},
+		{"skip backwards", "Offer", "Applied", "", true},
+		{"from final status", "Rejected", "Interview", "", true},
+		{"unknown target", "Applied", "Ghosted", "", true},
+		{"unknown current", "Waiting", "Interview", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := w.ValidateTransition(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateTransition(%q, %q) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ValidateTransition(%q, %q) = %q, want %q", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflowTransitionErrorMessage(t *testing.T) {
+	_, err := DefaultWorkflow().ValidateTransition("Applied", "Offer")
+	if err == nil {
+		t.Fatal("expected error but got nil")
+	}
+
+	errMsg := err.Error()
+	for _, want := range []string{"invalid status transition", "Applied", "Offer", "Screening", "--force"} {
+		if !strings.Contains(errMsg, want) {
+			t.Errorf("error message should contain %q, got: %s", want, errMsg)
+		}
+	}
+}
+
+func TestNewWorkflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *config.WorkflowConfig
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "nil config uses default",
+			cfg:  nil,
+			want: DefaultStatuses,
+		},
+		{
+			name: "custom workflow",
+			cfg: &config.WorkflowConfig{
+				Statuses:    []string{"Applied", "Hired"},
+				Transitions: map[string][]string{"applied": {"hired"}},
+			},
+			want: []string{"Applied", "Hired"},
+		},
+		{
+			name: "duplicate status",
+			cfg: &config.WorkflowConfig{
+				Statuses: []string{"Applied", "applied"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "transition to unknown status",
+			cfg: &config.WorkflowConfig{
+				Statuses:    []string{"Applied"},
+				Transitions: map[string][]string{"Applied": {"Hired"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "transition from unknown status",
+			cfg: &config.WorkflowConfig{
+				Statuses:    []string{"Applied"},
+				Transitions: map[string][]string{"Hired": {"Applied"}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewWorkflow(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewWorkflow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := w.Statuses(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Statuses() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkflowNext(t *testing.T) {
+	w := DefaultWorkflow()
+
+	if got, want := w.Next("applied"), []string{"Screening", "Interview", "Rejected", "Withdrawn"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Next(applied) = %v, want %v", got, want)
+	}
+	if got := w.Next("Rejected"); len(got) != 0 {
+		t.Errorf("Next(Rejected) = %v, want no transitions", got)
+	}
+}
+
+func TestWorkflowIsTerminal(t *testing.T) {
+	w := DefaultWorkflow()
+
+	for status, want := range map[string]bool{"rejected": true, "Withdrawn": true, "Offer": false, "Applied": false, "Hired": false} {
+		if got := w.IsTerminal(status); got != want {
+			t.Errorf("IsTerminal(%s) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package display
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+// BoardColumn is a column of the board, holding the applications in a status
+type BoardColumn struct {
+	Status       string              `json:"status"`
+	Applications []db.JobApplication `json:"applications"`
+}
+
+// GroupByStatus groups applications into a column per status, in the order of statuses.
+// Statuses outside of the list (e.g. added with --force) get columns at the end.
+func GroupByStatus(apps []db.JobApplication, statuses []string) []BoardColumn {
+	columns := make([]BoardColumn, len(statuses))
+	index := make(map[string]int, len(statuses))
+	for i, status := range statuses {
+		columns[i] = BoardColumn{Status: status, Applications: []db.JobApplication{}}
+		index[strings.ToLower(status)] = i
+	}
+	for _, app := range apps {
+		i, ok := index[strings.ToLower(app.Status)]
+		if !ok {
+			i = len(columns)
+			index[strings.ToLower(app.Status)] = i
+			columns = append(columns, BoardColumn{Status: app.Status})
+		}
+		columns[i].Applications = append(columns[i].Applications, app)
+	}
+	return columns
+}
+
+// BoardLines lays the board out in columns sharing the width, with a card of company,
+// position and age per application. Cards are truncated to the width of their column.
+// The card of the selected application is highlighted, and the line it starts at is reported (-1 if none).
+func BoardLines(columns []BoardColumn, width, selected int, now time.Time) ([]string, int) {
+	if len(columns) == 0 {
+		return nil, -1
+	}
+	// Columns are separated by " │ "
+	colWidth := max(4, (width-(len(columns)-1)*3)/len(columns))
+
+	// Cells of every column, line by line
+	cells := make([][]string, len(columns))
+	highlighted := make([][]bool, len(columns))
+	selectedLine := -1
+	height := 0
+	for i, col := range columns {
+		// The count stays visible when the title is truncated, unless the column is too narrow for it
+		count := " (" + strconv.Itoa(len(col.Applications)) + ")"
+		title := strings.ToUpper(col.Status)
+		if colWidth > len(count) {
+			title = runewidth.Truncate(title, colWidth-len(count), "…") + count
+		}
+		cells[i] = []string{
+			title,
+			strings.Repeat("─", colWidth),
+		}
+		highlighted[i] = []bool{false, false}
+		for _, app := range col.Applications {
+			if app.ID == selected {
+				selectedLine = len(cells[i])
+			}
+			card := []string{
+				app.Company,
+				app.Position,
+				"#" + strconv.Itoa(app.ID) + " · " + strings.TrimSuffix(FormatAgo(now.Sub(app.CreatedAt)), " ago"),
+				"",
+			}
+			for _, line := range card {
+				cells[i] = append(cells[i], line)
+				highlighted[i] = append(highlighted[i], app.ID == selected && line != "")
+			}
+		}
+		height = max(height, len(cells[i]))
+	}
+
+	lines := make([]string, height)
+	for row := range lines {
+		parts := make([]string, len(columns))
+		for i := range columns {
+			cell := ""
+			if row < len(cells[i]) {
+				cell = cells[i][row]
+			}
+			parts[i] = runewidth.FillRight(runewidth.Truncate(cell, colWidth, "…"), colWidth)
+			if row < len(highlighted[i]) && highlighted[i][row] {
+				parts[i] = "\x1b[7m" + parts[i] + "\x1b[0m"
+			}
+		}
+		lines[row] = strings.TrimRight(strings.Join(parts, " │ "), " ")
+	}
+	return lines, selectedLine
+}
+
+// RenderBoard renders applications grouped by status as a board fitting the width
+func RenderBoard(w io.Writer, columns []BoardColumn, width int) error {
+	lines, _ := BoardLines(columns, width, 0, time.Now())
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BoardDataset prepares the columns of a board for output, the table format rendering the board
+// fitting the width. CSV and TSV get a row per application along with the status of its column.
+func BoardDataset(columns []BoardColumn, width int) Dataset {
+	if columns == nil {
+		columns = []BoardColumn{}
+	}
+	var rows [][]string
+	for _, col := range columns {
+		for _, app := range col.Applications {
+			rows = append(rows, []string{col.Status, strconv.Itoa(app.ID), app.Company, app.Position, formatTime(app.CreatedAt)})
+		}
+	}
+	return Dataset{
+		Records: columns,
+		Header:  []string{"status", "id", "company", "position", "created_at"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderBoard(w, columns, width)
+		},
+	}
+}
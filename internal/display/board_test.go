@@ -0,0 +1,88 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package display
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+func TestGroupByStatus(t *testing.T) {
+	apps := []db.JobApplication{
+		{ID: 1, Company: "Google", Status: "Interview"},
+		{ID: 2, Company: "Apple", Status: "applied"},
+		{ID: 3, Company: "Meta", Status: "On hold"},
+		{ID: 4, Company: "Stripe", Status: "Applied"},
+	}
+	columns := GroupByStatus(apps, []string{"Applied", "Interview", "Offer"})
+
+	var got []string
+	for _, col := range columns {
+		ids := make([]string, len(col.Applications))
+		for i, app := range col.Applications {
+			ids[i] = app.Company
+		}
+		got = append(got, col.Status+":"+strings.Join(ids, ","))
+	}
+	want := "Applied:Apple,Stripe Interview:Google Offer: On hold:Meta"
+	if strings.Join(got, " ") != want {
+		t.Errorf("GroupByStatus() = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestBoardLines(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	columns := []BoardColumn{
+		{Status: "Applied", Applications: []db.JobApplication{
+			{ID: 1, Company: "Google", Position: "Senior Software Engineer", CreatedAt: now.Add(-72 * time.Hour)},
+			{ID: 2, Company: "Apple", Position: "Data Scientist", CreatedAt: now.Add(-2 * time.Hour)},
+		}},
+		{Status: "Interview", Applications: []db.JobApplication{
+			{ID: 3, Company: "Stripe", Position: "Backend Engineer", CreatedAt: now},
+		}},
+		{Status: "Offer"},
+	}
+
+	lines, selected := BoardLines(columns, 50, 2, now)
+	if selected != 6 {
+		t.Errorf("BoardLines() selected line = %d, want the first line of the second card", selected)
+	}
+	for _, line := range lines {
+		plain := strings.NewReplacer("\x1b[7m", "", "\x1b[0m", "").Replace(line)
+		if w := runewidth.StringWidth(plain); w > 50 {
+			t.Errorf("line %q is %d wide, want at most 50", plain, w)
+		}
+	}
+	for i, want := range []string{
+		"APPLIED (2)    │ INTERVIEW (1)  │ OFFER (0)",
+		"────────────── │ ────────────── │ ──────────────",
+		"Google         │ Stripe         │",
+		"Senior Softwa… │ Backend Engin… │",
+		"#1 · 3d        │ #3 · just now  │",
+	} {
+		if lines[i] != want {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want)
+		}
+	}
+	if !strings.HasPrefix(lines[6], "\x1b[7mApple") {
+		t.Errorf("selected card line = %q, want it highlighted", lines[6])
+	}
+
+	// Titles keep the count when truncated
+	lines, _ = BoardLines([]BoardColumn{{Status: "Screening"}}, 8, 0, now)
+	if lines[0] != "SCR… (0)" {
+		t.Errorf("truncated title = %q, want SCR… (0)", lines[0])
+	}
+
+	// The count is dropped when it does not fit the column
+	apps := make([]db.JobApplication, 10)
+	lines, _ = BoardLines([]BoardColumn{{Status: "Screening", Applications: apps}}, 4, 0, now)
+	if lines[0] != "SCR…" {
+		t.Errorf("narrow title = %q, want SCR…", lines[0])
+	}
+}
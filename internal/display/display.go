@@ -4,19 +4,314 @@ Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
 package display
 
 import (
-	"os"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/migrate"
+	"github.com/spolivin/jobtracker/v2/internal/doctor"
+	"github.com/spolivin/jobtracker/v2/internal/stats"
 )
 
-// RenderTable renders the job applications data in a table format
-func RenderTable(data []db.JobApplication) error {
-	table := tablewriter.NewWriter(os.Stdout)
-	table.Header([]string{"ID", "Company", "Position", "Status", "Created At", "Updated At"})
+// RenderTable renders the job applications data in a table format.
+// The footer reports the position of the rows among the total number of matching applications,
+// offset being the number of applications preceding the rows (negative if unknown).
+func RenderTable(w io.Writer, data []db.JobApplication, offset, total int) error {
+	// Showing tags only if some application carries them
+	withTags := false
 	for _, row := range data {
-		row := row.ConvertToStringSlice()
+		if len(row.Tags) > 0 {
+			withTags = true
+			break
+		}
+	}
+	header := []string{"ID", "Company", "Position", "Status", "Created At", "Updated At"}
+	if withTags {
+		header = append(header, "Tags")
+	}
+	table := tablewriter.NewWriter(w)
+	table.Header(header)
+	for _, app := range data {
+		row := app.ConvertToStringSlice()
+		if withTags {
+			row = append(row, strings.Join(app.Tags, ", "))
+		}
 		table.Append(row)
 	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, FormatFooter(len(data), offset, total))
+	return err
+}
+
+// FormatFooter describes which of the total matching applications are shown
+func FormatFooter(shown, offset, total int) string {
+	if shown == total && offset == 0 {
+		return fmt.Sprintf("Total: %d job application(s)", total)
+	}
+	if shown == 0 || offset < 0 {
+		return fmt.Sprintf("Showing %d of %d job application(s)", shown, total)
+	}
+	return fmt.Sprintf("Showing %d-%d of %d job application(s)", offset+1, offset+shown, total)
+}
+
+// RenderDetails renders a job application in a vertical key/value layout,
+// followed by its status history and notes
+func RenderDetails(w io.Writer, d db.ApplicationDetails) error {
+	tags := strings.Join(d.Tags, ", ")
+	if tags == "" {
+		tags = "-"
+	}
+	var since string
+	if n := len(d.History); n > 0 {
+		since = " (for " + FormatDuration(time.Since(d.History[n-1].ChangedAt)) + ")"
+	}
+	fields := [][2]string{
+		{"ID", strconv.Itoa(d.ID)},
+		{"Company", d.Company},
+		{"Position", d.Position},
+		{"Status", d.Status + since},
+		{"Tags", tags},
+		{"Created At", d.CreatedAt.Format(time.RFC3339)},
+		{"Updated At", d.UpdatedAt.Format(time.RFC3339)},
+	}
+	for _, field := range fields {
+		fmt.Fprintf(w, "%-11s %s\n", field[0]+":", field[1])
+	}
+
+	fmt.Fprintf(w, "\nStatus history (%d)\n", len(d.History))
+	if len(d.History) > 0 {
+		if err := RenderHistory(w, d.History); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "\nNotes (%d)\n", len(d.Notes))
+	for _, note := range d.Notes {
+		fmt.Fprintf(w, "#%d  %s\n", note.ID, note.CreatedAt.Format(time.RFC3339))
+		for _, line := range strings.Split(note.Content, "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+	return nil
+}
+
+// RenderTags renders tags with the number of applications carrying them in a table format
+func RenderTags(w io.Writer, tags []db.Tag) error {
+	table := tablewriter.NewWriter(w)
+	table.Header([]string{"Tag", "Applications"})
+	for _, tag := range tags {
+		table.Append([]string{tag.Name, strconv.Itoa(tag.Applications)})
+	}
 	return table.Render()
 }
+
+// RenderNotes renders the notes of a job application in a table format
+func RenderNotes(w io.Writer, notes []db.Note) error {
+	table := tablewriter.NewWriter(w)
+	table.Header([]string{"ID", "Note", "Created At", "Updated At"})
+	for _, note := range notes {
+		table.Append([]string{
+			strconv.Itoa(note.ID),
+			note.Content,
+			note.CreatedAt.Format(time.RFC3339),
+			note.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+	return table.Render()
+}
+
+// RenderMigrations renders the state of database migrations in a table format
+func RenderMigrations(w io.Writer, statuses []migrate.Status) error {
+	table := tablewriter.NewWriter(w)
+	table.Header([]string{"Version", "Status", "Applied At", "Checksum"})
+	for _, status := range statuses {
+		state, appliedAt, checksum := "pending", "-", "-"
+		if status.Applied {
+			state, appliedAt = "applied", "unknown"
+		}
+		if status.Modified {
+			state = "modified"
+		}
+		if !status.AppliedAt.IsZero() {
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.Checksum != "" {
+			checksum = status.Checksum[:min(12, len(status.Checksum))]
+		}
+		table.Append([]string{status.Version, state, appliedAt, checksum})
+	}
+	return table.Render()
+}
+
+// RenderProfiles renders connection profiles in a table format, marking the active one
+func RenderProfiles(w io.Writer, profiles []Profile) error {
+	table := tablewriter.NewWriter(w)
+	table.Header([]string{"", "Profile", "Driver", "Target"})
+	for _, p := range profiles {
+		marker := ""
+		if p.Active {
+			marker = "*"
+		}
+		table.Append([]string{marker, p.Name, p.Driver, p.Target})
+	}
+	return table.Render()
+}
+
+// RenderChecks renders diagnostic checks as a checklist, with hints below the problems found
+func RenderChecks(w io.Writer, checks []doctor.Check) error {
+	width := 0
+	for _, check := range checks {
+		width = max(width, len(check.Name))
+	}
+	for _, check := range checks {
+		if _, err := fmt.Fprintf(w, "[%s] %-*s  %s\n", strings.ToUpper(string(check.Status)), width, check.Name, check.Detail); err != nil {
+			return err
+		}
+		if check.Hint != "" && check.Status != doctor.Pass {
+			if _, err := fmt.Fprintf(w, "       %-*s  hint: %s\n", width, "", check.Hint); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RenderHistory renders the status history of a job application as a timeline
+// with the time spent in each stage
+func RenderHistory(w io.Writer, changes []db.StatusChange) error {
+	table := tablewriter.NewWriter(w)
+	table.Header([]string{"Status", "From", "Changed At", "Duration"})
+	for i, change := range changes {
+		from := change.FromStatus
+		if from == "" {
+			from = "-"
+		}
+		var duration string
+		if i+1 < len(changes) {
+			duration = FormatDuration(changes[i+1].ChangedAt.Sub(change.ChangedAt))
+		} else {
+			duration = FormatDuration(time.Since(change.ChangedAt)) + " (current)"
+		}
+		table.Append([]string{change.ToStatus, from, change.ChangedAt.Format(time.RFC3339), duration})
+	}
+	return table.Render()
+}
+
+// RenderFollowUps renders follow-ups in a table format, telling how long they are overdue or ahead of now
+func RenderFollowUps(w io.Writer, followUps []db.FollowUp, now time.Time) error {
+	table := tablewriter.NewWriter(w)
+	table.Header([]string{"ID", "Company", "Position", "Status", "Due At", "Due", "Reason"})
+	for _, f := range followUps {
+		reason := f.Rule
+		if f.Source == db.FollowUpScheduled {
+			reason = "scheduled"
+		}
+		table.Append([]string{
+			strconv.Itoa(f.ID),
+			f.Company,
+			f.Position,
+			f.Status,
+			f.DueAt.Local().Format("2006-01-02 15:04"),
+			FormatDue(f.DueAt.Sub(now)),
+			reason,
+		})
+	}
+	return table.Render()
+}
+
+// FormatDue formats the time left until a follow-up, e.g. "in 2d 3h" or "overdue by 1d 0h"
+func FormatDue(d time.Duration) string {
+	if d < 0 {
+		return "overdue by " + FormatDuration(-d)
+	}
+	return "in " + FormatDuration(d)
+}
+
+// FormatDuration formats a duration in days, hours and minutes
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// RenderStats renders the pipeline analytics as a series of tables
+func RenderStats(w io.Writer, report stats.Report) error {
+	fmt.Fprintf(w, "Total applications: %d\n\n", report.Total)
+
+	fmt.Fprintln(w, "Applications per status")
+	table := tablewriter.NewWriter(w)
+	table.Header([]string{"Status", "Count", "Percent"})
+	for _, row := range report.StatusCounts {
+		table.Append([]string{row.Status, strconv.Itoa(row.Count), formatPercent(row.Percent)})
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nPipeline funnel")
+	table = tablewriter.NewWriter(w)
+	table.Header([]string{"Stage", "Reached", "Of Total", "Conversion"})
+	for _, row := range report.Funnel {
+		table.Append([]string{row.Status, strconv.Itoa(row.Reached), formatPercent(row.PercentOfTotal), formatPercent(row.Conversion)})
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nResponses")
+	table = tablewriter.NewWriter(w)
+	table.Header([]string{"Responded", "Response Rate", "Median Days To First Response"})
+	table.Append([]string{
+		strconv.Itoa(report.Response.Responded),
+		formatPercent(report.Response.ResponseRate),
+		strconv.FormatFloat(report.Response.MedianDaysToFirstResponse, 'f', 1, 64),
+	})
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nApplications per week")
+	table = tablewriter.NewWriter(w)
+	table.Header([]string{"Week Of", "Count"})
+	for _, row := range report.Weekly {
+		table.Append([]string{row.WeekStart, strconv.Itoa(row.Count)})
+	}
+	if err := table.Render(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nTime in status (days)")
+	table = tablewriter.NewWriter(w)
+	table.Header([]string{"Status", "Samples", "Average", "Median"})
+	for _, row := range report.TimeInStatus {
+		table.Append([]string{
+			row.Status,
+			strconv.Itoa(row.Samples),
+			strconv.FormatFloat(row.AverageDays, 'f', 1, 64),
+			strconv.FormatFloat(row.MedianDays, 'f', 1, 64),
+		})
+	}
+	return table.Render()
+}
+
+// formatPercent formats a percentage with one decimal place
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64) + "%"
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package display
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want string
+	}{
+		{"zero", 0, "0m"},
+		{"negative", -time.Hour, "0m"},
+		{"minutes", 42 * time.Minute, "42m"},
+		{"hours and minutes", 3*time.Hour + 5*time.Minute, "3h 5m"},
+		{"days and hours", 50 * time.Hour, "2d 2h"},
+		{"exact days", 7 * 24 * time.Hour, "7d 0h"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDuration(tt.d); got != tt.want {
+				t.Errorf("FormatDuration(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFooter(t *testing.T) {
+	tests := []struct {
+		name   string
+		shown  int
+		offset int
+		total  int
+		want   string
+	}{
+		{"everything", 3, 0, 3, "Total: 3 job application(s)"},
+		{"first page", 20, 0, 245, "Showing 1-20 of 245 job application(s)"},
+		{"middle page", 20, 40, 245, "Showing 41-60 of 245 job application(s)"},
+		{"past the end", 0, 300, 245, "Showing 0 of 245 job application(s)"},
+		{"unknown offset", 20, -1, 245, "Showing 20 of 245 job application(s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatFooter(tt.shown, tt.offset, tt.total); got != tt.want {
+				t.Errorf("FormatFooter(%d, %d, %d) = %q, want %q", tt.shown, tt.offset, tt.total, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatDue(t *testing.T) {
+	tests := []struct {
+		name string
+		d    time.Duration
+		want string
+	}{
+		{"ahead", 50 * time.Hour, "in 2d 2h"},
+		{"now", 0, "in 0m"},
+		{"overdue", -3 * time.Hour, "overdue by 3h 0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDue(tt.d); got != tt.want {
+				t.Errorf("FormatDue(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package display
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"github.com/spolivin/jobtracker/v2/internal/db/migrate"
+	"github.com/spolivin/jobtracker/v2/internal/doctor"
+	"github.com/spolivin/jobtracker/v2/internal/stats"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is an output format of read-style commands
+type Format string
+
+const (
+	FormatTable  Format = "table"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatCSV    Format = "csv"
+	FormatTSV    Format = "tsv"
+	FormatYAML   Format = "yaml"
+)
+
+// Formats lists the supported output formats
+var Formats = []Format{FormatTable, FormatJSON, FormatNDJSON, FormatCSV, FormatTSV, FormatYAML}
+
+// ParseFormat parses an output format name (case-insensitive)
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	for _, f := range Formats {
+		if f == format {
+			return f, nil
+		}
+	}
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = string(f)
+	}
+	return "", fmt.Errorf("unsupported output format: %q (allowed: %s)", name, strings.Join(names, ", "))
+}
+
+// Dataset holds records of a read-style command in the shapes needed by every output format
+type Dataset struct {
+	// Records is serialized as JSON, NDJSON (one line per element of a slice) or YAML
+	Records any
+	// Header and Rows are written as CSV or TSV, with the header matching the JSON field names
+	Header []string
+	Rows   [][]string
+	// Table renders the records in a table format
+	Table func(w io.Writer) error
+}
+
+// Render writes the dataset to w in the given format
+func Render(w io.Writer, format Format, d Dataset) error {
+	switch format {
+	case FormatTable:
+		return d.Table(w)
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(d.Records)
+	case FormatNDJSON:
+		return writeNDJSON(w, d.Records)
+	case FormatYAML:
+		return writeYAML(w, d.Records)
+	case FormatCSV, FormatTSV:
+		if d.Header == nil {
+			return fmt.Errorf("output format %q is not supported for this data", format)
+		}
+		writer := csv.NewWriter(w)
+		if format == FormatTSV {
+			writer.Comma = '\t'
+		}
+		if err := writer.Write(d.Header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(d.Rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	default:
+		return fmt.Errorf("unsupported output format: %q", format)
+	}
+}
+
+// writeNDJSON writes every element of a slice as a JSON line, or a single line for other values
+func writeNDJSON(w io.Writer, records any) error {
+	encoder := json.NewEncoder(w)
+	v := reflect.ValueOf(records)
+	if v.Kind() != reflect.Slice {
+		return encoder.Encode(records)
+	}
+	for i := 0; i < v.Len(); i++ {
+		if err := encoder.Encode(v.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeYAML writes records as YAML, keeping the field names and order of their JSON encoding
+func writeYAML(w io.Writer, records any) error {
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	// JSON is valid YAML, so it is decoded into a node tree and re-encoded in block style
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// resetStyle clears the flow and quoting styles inherited from JSON
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// ApplicationsDataset prepares job applications for output,
+// offset and total being passed on to the table footer
+func ApplicationsDataset(apps []db.JobApplication, offset, total int) Dataset {
+	if apps == nil {
+		apps = []db.JobApplication{}
+	}
+	rows := make([][]string, len(apps))
+	for i, app := range apps {
+		rows[i] = append(app.ConvertToStringSlice(), strings.Join(app.Tags, ","))
+	}
+	return Dataset{
+		Records: apps,
+		Header:  []string{"id", "company", "position", "status", "created_at", "updated_at", "tags"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderTable(w, apps, offset, total)
+		},
+	}
+}
+
+// DetailsDataset prepares a job application with its notes and status history for output.
+// CSV and TSV get a single row, with notes and status changes on separate lines of their columns.
+func DetailsDataset(d db.ApplicationDetails) Dataset {
+	if d.History == nil {
+		d.History = []db.StatusChange{}
+	}
+	notes := make([]string, len(d.Notes))
+	for i, note := range d.Notes {
+		notes[i] = note.Content
+	}
+	history := make([]string, len(d.History))
+	for i, change := range d.History {
+		history[i] = change.ChangedAt.Format(time.RFC3339) + " " + change.ToStatus
+	}
+	row := append(d.ConvertToStringSlice(), strings.Join(d.Tags, ","), strings.Join(notes, "\n"), strings.Join(history, "\n"))
+	return Dataset{
+		Records: d,
+		Header:  []string{"id", "company", "position", "status", "created_at", "updated_at", "tags", "notes", "history"},
+		Rows:    [][]string{row},
+		Table: func(w io.Writer) error {
+			return RenderDetails(w, d)
+		},
+	}
+}
+
+// NotesDataset prepares notes for output
+func NotesDataset(notes []db.Note) Dataset {
+	if notes == nil {
+		notes = []db.Note{}
+	}
+	rows := make([][]string, len(notes))
+	for i, note := range notes {
+		rows[i] = []string{
+			strconv.Itoa(note.ID),
+			strconv.Itoa(note.ApplicationID),
+			note.Content,
+			note.CreatedAt.Format(time.RFC3339),
+			note.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+	return Dataset{
+		Records: notes,
+		Header:  []string{"id", "application_id", "content", "created_at", "updated_at"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderNotes(w, notes)
+		},
+	}
+}
+
+// HistoryDataset prepares status changes for output
+func HistoryDataset(changes []db.StatusChange) Dataset {
+	if changes == nil {
+		changes = []db.StatusChange{}
+	}
+	rows := make([][]string, len(changes))
+	for i, change := range changes {
+		rows[i] = []string{
+			strconv.Itoa(change.ID),
+			strconv.Itoa(change.ApplicationID),
+			change.FromStatus,
+			change.ToStatus,
+			change.ChangedAt.Format(time.RFC3339),
+		}
+	}
+	return Dataset{
+		Records: changes,
+		Header:  []string{"id", "application_id", "from_status", "to_status", "changed_at"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderHistory(w, changes)
+		},
+	}
+}
+
+// FollowUpsDataset prepares follow-ups for output
+func FollowUpsDataset(followUps []db.FollowUp) Dataset {
+	if followUps == nil {
+		followUps = []db.FollowUp{}
+	}
+	rows := make([][]string, len(followUps))
+	for i, f := range followUps {
+		rows[i] = []string{
+			strconv.Itoa(f.ID),
+			f.Company,
+			f.Position,
+			f.Status,
+			f.DueAt.Format(time.RFC3339),
+			f.Source,
+			f.Rule,
+		}
+	}
+	return Dataset{
+		Records: followUps,
+		Header:  []string{"id", "company", "position", "status", "due_at", "source", "rule"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderFollowUps(w, followUps, time.Now())
+		},
+	}
+}
+
+// TagsDataset prepares tags for output
+func TagsDataset(tags []db.Tag) Dataset {
+	if tags == nil {
+		tags = []db.Tag{}
+	}
+	rows := make([][]string, len(tags))
+	for i, tag := range tags {
+		rows[i] = []string{strconv.Itoa(tag.ID), tag.Name, strconv.Itoa(tag.Applications)}
+	}
+	return Dataset{
+		Records: tags,
+		Header:  []string{"id", "name", "applications"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderTags(w, tags)
+		},
+	}
+}
+
+// StatsDataset prepares a statistics report for output.
+// The report is nested, so it cannot be written as CSV or TSV.
+func StatsDataset(report stats.Report) Dataset {
+	return Dataset{
+		Records: report,
+		Table: func(w io.Writer) error {
+			return RenderStats(w, report)
+		},
+	}
+}
+
+// MigrationsDataset prepares migration statuses for output
+func MigrationsDataset(statuses []migrate.Status) Dataset {
+	if statuses == nil {
+		statuses = []migrate.Status{}
+	}
+	rows := make([][]string, len(statuses))
+	for i, status := range statuses {
+		rows[i] = []string{
+			status.Version,
+			strconv.FormatBool(status.Applied),
+			formatTime(status.AppliedAt),
+			status.Checksum,
+			strconv.FormatBool(status.Modified),
+		}
+	}
+	return Dataset{
+		Records: statuses,
+		Header:  []string{"version", "applied", "applied_at", "checksum", "modified"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderMigrations(w, statuses)
+		},
+	}
+}
+
+// ChecksDataset prepares diagnostic checks for output
+func ChecksDataset(checks []doctor.Check) Dataset {
+	rows := make([][]string, len(checks))
+	for i, check := range checks {
+		rows[i] = []string{check.Name, string(check.Status), check.Detail, check.Hint}
+	}
+	return Dataset{
+		Records: checks,
+		Header:  []string{"name", "status", "detail", "hint"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderChecks(w, checks)
+		},
+	}
+}
+
+// Profile is a connection profile as listed by the config command
+type Profile struct {
+	Name   string `json:"name"`
+	Driver string `json:"driver"`
+	Target string `json:"target"`
+	Active bool   `json:"active"`
+}
+
+// ProfilesDataset prepares the connection profiles of a config file for output,
+// marking the selected one as active
+func ProfilesDataset(f *config.File, selected string) Dataset {
+	profiles := make([]Profile, 0, len(f.Profiles))
+	rows := make([][]string, 0, len(f.Profiles))
+	for _, name := range f.Names() {
+		c := f.Profiles[name]
+		p := Profile{Name: name, Driver: c.DriverName(), Target: c.Target(), Active: name == selected}
+		profiles = append(profiles, p)
+		rows = append(rows, []string{p.Name, p.Driver, p.Target, strconv.FormatBool(p.Active)})
+	}
+	return Dataset{
+		Records: profiles,
+		Header:  []string{"name", "driver", "target", "active"},
+		Rows:    rows,
+		Table: func(w io.Writer) error {
+			return RenderProfiles(w, profiles)
+		},
+	}
+}
+
+// formatTime formats a time in RFC 3339, leaving zero times empty
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
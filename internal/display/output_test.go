@@ -0,0 +1,156 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package display
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{"table", FormatTable, false},
+		{" JSON ", FormatJSON, false},
+		{"ndjson", FormatNDJSON, false},
+		{"yaml", FormatYAML, false},
+		{"xml", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFormat(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderApplications(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	apps := []db.JobApplication{
+		{ID: 1, Company: "Google", Position: "Engineer", Status: "Applied", CreatedAt: created, UpdatedAt: created, Tags: []string{"backend", "remote"}},
+		{ID: 2, Company: "Acme, Inc.", Position: "SRE", Status: "Offer", CreatedAt: created, UpdatedAt: created},
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatCSV, "id,company,position,status,created_at,updated_at,tags\n" +
+			"1,Google,Engineer,Applied,2026-01-15T10:30:00Z,2026-01-15T10:30:00Z,\"backend,remote\"\n" +
+			"2,\"Acme, Inc.\",SRE,Offer,2026-01-15T10:30:00Z,2026-01-15T10:30:00Z,\n"},
+		{FormatTSV, "id\tcompany\tposition\tstatus\tcreated_at\tupdated_at\ttags\n" +
+			"1\tGoogle\tEngineer\tApplied\t2026-01-15T10:30:00Z\t2026-01-15T10:30:00Z\tbackend,remote\n" +
+			"2\tAcme, Inc.\tSRE\tOffer\t2026-01-15T10:30:00Z\t2026-01-15T10:30:00Z\t\n"},
+		{FormatNDJSON, `{"id":1,"company":"Google","position":"Engineer","status":"Applied","created_at":"2026-01-15T10:30:00Z","updated_at":"2026-01-15T10:30:00Z","tags":["backend","remote"]}` + "\n" +
+			`{"id":2,"company":"Acme, Inc.","position":"SRE","status":"Offer","created_at":"2026-01-15T10:30:00Z","updated_at":"2026-01-15T10:30:00Z"}` + "\n"},
+		{FormatYAML, "- id: 1\n  company: Google\n  position: Engineer\n  status: Applied\n" +
+			"  created_at: \"2026-01-15T10:30:00Z\"\n  updated_at: \"2026-01-15T10:30:00Z\"\n  tags:\n    - backend\n    - remote\n" +
+			"- id: 2\n  company: Acme, Inc.\n  position: SRE\n  status: Offer\n" +
+			"  created_at: \"2026-01-15T10:30:00Z\"\n  updated_at: \"2026-01-15T10:30:00Z\"\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.format, ApplicationsDataset(apps, 0, len(apps))); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderEmpty(t *testing.T) {
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatJSON, "[]\n"},
+		{FormatNDJSON, ""},
+		{FormatYAML, "[]\n"},
+		{FormatCSV, "id,application_id,content,created_at,updated_at\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.format, NotesDataset(nil)); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if err := Render(&bytes.Buffer{}, FormatCSV, Dataset{Records: struct{}{}}); err == nil {
+		t.Error("Render() of nested data as CSV should return error")
+	}
+}
+
+func TestRenderDetails(t *testing.T) {
+	created := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	screened := created.Add(48 * time.Hour)
+	details := db.ApplicationDetails{
+		JobApplication: db.JobApplication{
+			ID: 1, Company: "Google", Position: "Engineer", Status: "Screening", CreatedAt: created, UpdatedAt: screened,
+			Tags:  []string{"remote"},
+			Notes: []db.Note{{ID: 3, ApplicationID: 1, Content: "Recruiter call\nbring questions", CreatedAt: created, UpdatedAt: created}},
+		},
+		History: []db.StatusChange{
+			{ID: 1, ApplicationID: 1, ToStatus: "Applied", ChangedAt: created},
+			{ID: 2, ApplicationID: 1, FromStatus: "Applied", ToStatus: "Screening", ChangedAt: screened},
+		},
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{FormatCSV, "id,company,position,status,created_at,updated_at,tags,notes,history\n" +
+			"1,Google,Engineer,Screening,2026-01-15T10:30:00Z,2026-01-17T10:30:00Z,remote,\"Recruiter call\nbring questions\"," +
+			"\"2026-01-15T10:30:00Z Applied\n2026-01-17T10:30:00Z Screening\"\n"},
+		{FormatNDJSON, `{"id":1,"company":"Google","position":"Engineer","status":"Screening","created_at":"2026-01-15T10:30:00Z","updated_at":"2026-01-17T10:30:00Z",` +
+			`"tags":["remote"],"notes":[{"id":3,"application_id":1,"content":"Recruiter call\nbring questions","created_at":"2026-01-15T10:30:00Z","updated_at":"2026-01-15T10:30:00Z"}],` +
+			`"history":[{"id":1,"application_id":1,"from_status":"","to_status":"Applied","changed_at":"2026-01-15T10:30:00Z"},` +
+			`{"id":2,"application_id":1,"from_status":"Applied","to_status":"Screening","changed_at":"2026-01-17T10:30:00Z"}]}` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Render(&buf, tt.format, DetailsDataset(details)); err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Render() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := Render(&buf, FormatTable, DetailsDataset(details)); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	for _, want := range []string{"Company:    Google\n", "Tags:       remote\n", "Status history (2)\n", "Notes (1)\n#3  2026-01-15T10:30:00Z\n    Recruiter call\n    bring questions\n"} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("Render() table =\n%s\nwant it to contain %q", buf.String(), want)
+		}
+	}
+}
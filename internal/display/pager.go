@@ -0,0 +1,64 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package display
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// defaultPager is used when $PAGER is not set
+const defaultPager = "less -FRX"
+
+// Paged renders output through render and shows it in a pager ($PAGER, falling back to less)
+// when stdout is a terminal and the output does not fit on the screen.
+// Otherwise the output is written to stdout as is.
+func Paged(render func(w io.Writer) error) error {
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return render(os.Stdout)
+	}
+	var buf bytes.Buffer
+	if err := render(&buf); err != nil {
+		return err
+	}
+	_, height, err := term.GetSize(fd)
+	if err != nil || bytes.Count(buf.Bytes(), []byte("\n")) < height {
+		_, err := buf.WriteTo(os.Stdout)
+		return err
+	}
+	if err := runPager(pagerCommand(), buf.Bytes()); err != nil {
+		// Falling back to plain output if the pager cannot be run
+		_, err := buf.WriteTo(os.Stdout)
+		return err
+	}
+	return nil
+}
+
+// pagerCommand returns the pager command line, or nil if paging is disabled with an empty $PAGER.
+func pagerCommand() []string {
+	pager, ok := os.LookupEnv("PAGER")
+	if !ok {
+		pager = defaultPager
+	}
+	return strings.Fields(pager)
+}
+
+// runPager pipes output into the pager command.
+func runPager(command []string, output []byte) error {
+	if len(command) == 0 || command[0] == "cat" {
+		_, err := os.Stdout.Write(output)
+		return err
+	}
+	pager := exec.Command(command[0], command[1:]...)
+	pager.Stdin = bytes.NewReader(output)
+	pager.Stdout = os.Stdout
+	pager.Stderr = os.Stderr
+	return pager.Run()
+}
@@ -0,0 +1,161 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package display
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// templateExt is the file extension of named templates
+const templateExt = ".tmpl"
+
+// colorCodes maps color names usable in templates to ANSI escape codes
+var colorCodes = map[string]string{
+	"bold":    "1",
+	"dim":     "2",
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"gray":    "90",
+}
+
+// ParseTemplate parses a text/template applied to every record of a read-style command.
+// The template may define "header" and "footer" templates that are applied once to all records.
+// Colors are only emitted if colors is set.
+func ParseTemplate(text string, colors bool) (*template.Template, error) {
+	tmpl, err := template.New("format").Funcs(TemplateFuncs(colors, time.Now)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// LoadTemplate reads the named template from dir (<name>.tmpl).
+func LoadTemplate(dir, name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid template name: %q", name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+templateExt))
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("template %q not found in %s", name, dir)
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// RenderTemplate applies the template to every record, writing each result on its own line.
+func RenderTemplate(w io.Writer, tmpl *template.Template, records any) error {
+	if header := tmpl.Lookup("header"); header != nil {
+		if err := header.Execute(w, records); err != nil {
+			return err
+		}
+	}
+	v := reflect.ValueOf(records)
+	items := []any{records}
+	if v.Kind() == reflect.Slice {
+		items = make([]any, v.Len())
+		for i := range items {
+			items[i] = v.Index(i).Interface()
+		}
+	}
+	for _, item := range items {
+		var line strings.Builder
+		if err := tmpl.Execute(&line, item); err != nil {
+			return err
+		}
+		text := line.String()
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		if _, err := io.WriteString(w, text); err != nil {
+			return err
+		}
+	}
+	if footer := tmpl.Lookup("footer"); footer != nil {
+		return footer.Execute(w, records)
+	}
+	return nil
+}
+
+// TemplateFuncs returns the helper functions available in format templates.
+// Relative dates are computed against now.
+func TemplateFuncs(colors bool, now func() time.Time) template.FuncMap {
+	return template.FuncMap{
+		"ago": func(t time.Time) string {
+			return FormatAgo(now().Sub(t))
+		},
+		"date": func(layout string, t time.Time) string {
+			return t.Local().Format(layout)
+		},
+		"pad": func(width int, v any) string {
+			s := fmt.Sprint(v)
+			return s + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s)))
+		},
+		"padLeft": func(width int, v any) string {
+			s := fmt.Sprint(v)
+			return strings.Repeat(" ", max(0, width-utf8.RuneCountInString(s))) + s
+		},
+		"trunc": func(width int, v any) string {
+			s := []rune(fmt.Sprint(v))
+			if len(s) <= width {
+				return string(s)
+			}
+			if width < 1 {
+				return ""
+			}
+			return string(s[:width-1]) + "…"
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"join": func(sep string, items []string) string {
+			return strings.Join(items, sep)
+		},
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"color": func(name string, v any) (string, error) {
+			code, ok := colorCodes[name]
+			if !ok {
+				return "", fmt.Errorf("unknown color: %q", name)
+			}
+			if !colors {
+				return fmt.Sprint(v), nil
+			}
+			return "\x1b[" + code + "m" + fmt.Sprint(v) + "\x1b[0m", nil
+		},
+	}
+}
+
+// FormatAgo formats the time elapsed since an event, e.g. "3d ago"
+func FormatAgo(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d/(24*time.Hour)))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(d/(30*24*time.Hour)))
+	default:
+		return fmt.Sprintf("%dy ago", int(d/(365*24*time.Hour)))
+	}
+}
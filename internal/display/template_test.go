@@ -0,0 +1,115 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package display
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+func TestTemplateFuncs(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	app := db.JobApplication{ID: 7, Company: "Google", Status: "Interview", UpdatedAt: now.Add(-50 * time.Hour), Tags: []string{"remote", "backend"}}
+
+	tests := []struct {
+		name   string
+		text   string
+		colors bool
+		want   string
+	}{
+		{"fields", "{{.ID}} {{.Company}} ({{.Status}})", false, "7 Google (Interview)"},
+		{"relative date", "{{ago .UpdatedAt}}", false, "2d ago"},
+		{"padding", "[{{pad 8 .Company}}][{{padLeft 4 .ID}}]", false, "[Google  ][   7]"},
+		{"truncation", "{{trunc 4 .Company}}|{{trunc 10 .Company}}", false, "Goo…|Google"},
+		{"case and join", "{{upper .Status}} {{join \"+\" .Tags}}", false, "INTERVIEW remote+backend"},
+		{"json", "{{json .Tags}}", false, `["remote","backend"]`},
+		{"color disabled", "{{color \"green\" .Status}}", false, "Interview"},
+		{"color enabled", "{{color \"green\" .Status}}", true, "\x1b[32mInterview\x1b[0m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := template.New("test").Funcs(TemplateFuncs(tt.colors, func() time.Time { return now })).Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			var buf bytes.Buffer
+			if err := tmpl.Execute(&buf, app); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	apps := []db.JobApplication{{ID: 1, Company: "Google"}, {ID: 2, Company: "Apple"}}
+	text := `{{define "header"}}{{len .}} applications:
+{{end}}- {{.Company}}{{define "footer"}}done
+{{end}}`
+	tmpl, err := ParseTemplate(text, false)
+	if err != nil {
+		t.Fatalf("ParseTemplate() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if err := RenderTemplate(&buf, tmpl, apps); err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+	if want := "2 applications:\n- Google\n- Apple\ndone\n"; buf.String() != want {
+		t.Errorf("RenderTemplate() = %q, want %q", buf.String(), want)
+	}
+
+	if _, err := ParseTemplate("{{.Company", false); err == nil {
+		t.Error("ParseTemplate() with unclosed action should return error")
+	}
+	tmpl, _ = ParseTemplate(`{{color "plaid" .Company}}`, false)
+	if err := RenderTemplate(&bytes.Buffer{}, tmpl, apps); err == nil || !strings.Contains(err.Error(), "unknown color") {
+		t.Errorf("RenderTemplate() with unknown color error = %v", err)
+	}
+}
+
+func TestLoadTemplate(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "slack.tmpl"), []byte("• {{.Company}}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if text, err := LoadTemplate(dir, "slack"); err != nil || text != "• {{.Company}}" {
+		t.Errorf("LoadTemplate() = %q, %v", text, err)
+	}
+	for _, name := range []string{"missing", "../slack", "", ".hidden"} {
+		if _, err := LoadTemplate(dir, name); err == nil {
+			t.Errorf("LoadTemplate(%q) should return error", name)
+		}
+	}
+}
+
+func TestFormatAgo(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{30 * time.Second, "just now"},
+		{5 * time.Minute, "5m ago"},
+		{3 * time.Hour, "3h ago"},
+		{50 * time.Hour, "2d ago"},
+		{65 * 24 * time.Hour, "2mo ago"},
+		{800 * 24 * time.Hour, "2y ago"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatAgo(tt.d); got != tt.want {
+			t.Errorf("FormatAgo(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package doctor
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"github.com/spolivin/jobtracker/v2/internal/db/migrate"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	Pass Status = "pass"
+	Warn Status = "warn"
+	Fail Status = "fail"
+	Skip Status = "skip"
+)
+
+// Check is a diagnostic step with its outcome and a hint on fixing the problem found.
+type Check struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+// Options configures the diagnostics.
+type Options struct {
+	// Profile selects the connection profile as described in config.LoadConfig.
+	Profile string
+	// Password returns the Postgres password of a connection, config.GetPassword by default.
+	Password func(c *config.ConnectionConfig) (string, error)
+}
+
+// Failures counts the failed checks.
+func Failures(checks []Check) int {
+	failed := 0
+	for _, check := range checks {
+		if check.Status == Fail {
+			failed++
+		}
+	}
+	return failed
+}
+
+// step runs a check, filling in its outcome.
+type step struct {
+	name string
+	run  func(ctx context.Context, check *Check)
+}
+
+// doctor holds the state shared by the checks.
+type doctor struct {
+	opts Options
+	cfg  *config.ConnectionConfig
+	conn *sql.DB
+}
+
+// Run checks the configuration and the database of a profile step by step.
+// The checks after a failed one are skipped, as they depend on it.
+func Run(ctx context.Context, opts Options) []Check {
+	if opts.Password == nil {
+		opts.Password = config.GetPassword
+	}
+	d := &doctor{opts: opts}
+	defer func() {
+		if d.conn != nil {
+			d.conn.Close()
+		}
+	}()
+
+	steps := []step{
+		{"Config file", d.checkConfigFile},
+		{"Profile", d.checkProfile},
+		{"DNS resolution", d.checkDNS},
+		{"TCP connection", d.checkTCP},
+		{"Authentication", d.checkAuthentication},
+		{"Server version", d.checkServerVersion},
+		{"TLS", d.checkTLS},
+		{"Applications table", d.checkApplicationsTable},
+		{"Migrations", d.checkMigrations},
+	}
+	checks := make([]Check, 0, len(steps))
+	failed := ""
+	for _, s := range steps {
+		check := Check{Name: s.name, Status: Pass}
+		if failed != "" {
+			check.Status, check.Detail = Skip, fmt.Sprintf("skipped, %s failed", strings.ToLower(failed))
+		} else {
+			s.run(ctx, &check)
+			if check.Status == Fail {
+				failed = s.name
+			}
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// checkConfigFile checks that the config file exists and is only accessible by its owner.
+func (d *doctor) checkConfigFile(ctx context.Context, check *Check) {
+	path, err := config.FilePath()
+	if err != nil {
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = "Set HOME (or XDG_CONFIG_HOME) so that the config directory can be found"
+		return
+	}
+	info, err := os.Stat(path)
+	switch {
+	case os.IsNotExist(err) && os.Getenv(config.DatabaseURLEnv) != "":
+		check.Detail = fmt.Sprintf("%s not found, using %s", path, config.DatabaseURLEnv)
+	case os.IsNotExist(err):
+		check.Status, check.Detail = Fail, path+" not found"
+		check.Hint = "Run `jobtracker configure` to create it"
+	case err != nil:
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = "Check the permissions of the config directory"
+	case runtime.GOOS != "windows" && info.Mode().Perm()&0077 != 0:
+		check.Status, check.Detail = Warn, fmt.Sprintf("%s is accessible by group or others (%s)", path, info.Mode().Perm())
+		check.Hint = "Run `chmod 600 " + path + "`"
+	default:
+		check.Detail = fmt.Sprintf("%s (%s)", path, info.Mode().Perm())
+	}
+}
+
+// checkProfile loads and validates the selected profile.
+func (d *doctor) checkProfile(ctx context.Context, check *Check) {
+	cfg, err := config.LoadConfig(d.opts.Profile)
+	if err != nil {
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = "Run `jobtracker config list` to see the profiles, or `jobtracker configure --profile <name>` to create one"
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		check.Status, check.Detail = Fail, strings.ReplaceAll(err.Error(), "\n", "; ")
+		check.Hint = fmt.Sprintf("Run `jobtracker configure --profile %s` to fix the settings", cfg.KeyringUser())
+		return
+	}
+	d.cfg = cfg
+	check.Detail = fmt.Sprintf("%s (%s, %s)", cfg.KeyringUser(), cfg.DriverName(), cfg.Target())
+}
+
+// network reports whether the connection goes over the network, skipping the check otherwise.
+func (d *doctor) network(check *Check) bool {
+	if d.cfg.DriverName() == config.DriverSQLite {
+		check.Status, check.Detail = Skip, "not needed for SQLite"
+		return false
+	}
+	if host, _ := d.cfg.Endpoint(); strings.HasPrefix(host, "/") {
+		check.Status, check.Detail = Skip, "connecting through the Unix socket in "+host
+		return false
+	}
+	return true
+}
+
+// checkDNS resolves the host of the database server.
+func (d *doctor) checkDNS(ctx context.Context, check *Check) {
+	if !d.network(check) {
+		return
+	}
+	host, _ := d.cfg.Endpoint()
+	if net.ParseIP(host) != nil {
+		check.Detail = host + " is an IP address"
+		return
+	}
+	timeout, _, _ := d.cfg.Timeouts()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = "Check the host name for typos, and your DNS or VPN settings"
+		return
+	}
+	check.Detail = fmt.Sprintf("%s resolves to %s", host, strings.Join(addrs, ", "))
+}
+
+// checkTCP opens a TCP connection to the database server.
+func (d *doctor) checkTCP(ctx context.Context, check *Check) {
+	if !d.network(check) {
+		return
+	}
+	address := net.JoinHostPort(d.cfg.Endpoint())
+	timeout, _, _ := d.cfg.Timeouts()
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = "Check that Postgres is running and accepts connections on " + address +
+			" (listen_addresses, port and firewall rules)"
+		return
+	}
+	conn.Close()
+	check.Detail = address + " is reachable"
+}
+
+// checkAuthentication connects to the database with the password of the profile.
+func (d *doctor) checkAuthentication(ctx context.Context, check *Check) {
+	if d.cfg.DriverName() == config.DriverSQLite {
+		conn, err := db.Connect(ctx, d.cfg, "")
+		if err != nil {
+			check.Status, check.Detail = Fail, err.Error()
+			check.Hint = "Check that the directory of the database file exists and is writable"
+			return
+		}
+		d.conn = conn
+		check.Detail = "not needed for SQLite, opened " + d.cfg.Target()
+		return
+	}
+	source, err := d.cfg.PasswordSource()
+	if err != nil {
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = "Fix the password source of the profile, see `jobtracker config`"
+		return
+	}
+	password, err := d.opts.Password(d.cfg)
+	if err != nil {
+		check.Status, check.Detail = Fail, fmt.Sprintf("failed to get the password from %s: %v", source, err)
+		check.Hint = "Set DB_PASS or PGPASSWORD when running without a terminal, or fix the password source"
+		return
+	}
+	conn, err := db.Connect(ctx, d.cfg, password)
+	if err != nil {
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = connectHint(err, source)
+		return
+	}
+	d.conn = conn
+	host, _ := d.cfg.Endpoint()
+	check.Detail = fmt.Sprintf("connected to %s with the password from %s", host, source)
+}
+
+// connectHint suggests a fix for a failed Postgres connection.
+func connectHint(err error, source config.PasswordSource) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "28P01":
+			return fmt.Sprintf("The password from %s was rejected, check it or use another password source", source)
+		case "28000":
+			return "The server does not allow this user, host or database, check pg_hba.conf on the server"
+		case "3D000":
+			return "The database does not exist, create it (e.g. `createdb`) or fix the database name"
+		case "53300":
+			return "The server has no free connection slots, try again later or raise max_connections"
+		}
+	}
+	message := err.Error()
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || strings.Contains(message, "timeout"):
+		return "The server did not answer in time, check the network or raise connect_timeout"
+	case strings.Contains(message, "SSL is not enabled"):
+		return "The server does not support TLS, set sslmode to disable or enable ssl on the server"
+	case strings.Contains(message, "x509") || strings.Contains(message, "certificate"):
+		return "The server certificate could not be verified, check sslrootcert or the host name"
+	}
+	return "Check the connection settings of the profile with `jobtracker config`"
+}
+
+// checkServerVersion reports the version of the database server.
+func (d *doctor) checkServerVersion(ctx context.Context, check *Check) {
+	query := `SHOW server_version`
+	if db.DialectOf(d.conn) == db.SQLite {
+		query = `SELECT 'SQLite ' || sqlite_version()`
+	}
+	var version string
+	if err := d.conn.QueryRowContext(ctx, query).Scan(&version); err != nil {
+		check.Status, check.Detail = Warn, err.Error()
+		check.Hint = "The server version could not be read, the connection may be restricted"
+		return
+	}
+	if db.DialectOf(d.conn) == db.Postgres {
+		version = "PostgreSQL " + version
+	}
+	check.Detail = version
+}
+
+// checkTLS reports whether the connection to the database server is encrypted.
+func (d *doctor) checkTLS(ctx context.Context, check *Check) {
+	if !d.network(check) {
+		return
+	}
+	var ssl bool
+	var version, cipher sql.NullString
+	err := d.conn.QueryRowContext(ctx,
+		`SELECT ssl, version, cipher FROM pg_stat_ssl WHERE pid = pg_backend_pid()`,
+	).Scan(&ssl, &version, &cipher)
+	if err != nil {
+		check.Status, check.Detail = Warn, "TLS status unknown: "+err.Error()
+		return
+	}
+	if !ssl {
+		check.Status, check.Detail = Warn, "connection is not encrypted"
+		check.Hint = "Set sslmode to require or verify-full with `jobtracker configure` if the server is not local"
+		return
+	}
+	check.Detail = fmt.Sprintf("encrypted with %s (%s)", version.String, cipher.String)
+}
+
+// checkApplicationsTable checks that the table of job applications exists.
+func (d *doctor) checkApplicationsTable(ctx context.Context, check *Check) {
+	exists, err := db.CheckTableExists(ctx, d.conn, "applications")
+	if err != nil {
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = "Check that the user may read the schema of the database"
+		return
+	}
+	if !exists {
+		check.Status, check.Detail = Fail, "table applications not found"
+		check.Hint = "Run `jobtracker migrate` to create the schema"
+		return
+	}
+	check.Detail = "table applications exists"
+}
+
+// checkMigrations compares the applied migrations with the embedded ones.
+func (d *doctor) checkMigrations(ctx context.Context, check *Check) {
+	statuses, err := migrate.Statuses(ctx, d.conn)
+	if err != nil {
+		check.Status, check.Detail = Fail, err.Error()
+		check.Hint = "Run `jobtracker migrate status` for details"
+		return
+	}
+	c := migrate.Classify(statuses)
+	switch {
+	case len(c.Modified) > 0:
+		check.Status, check.Detail = Fail, (&migrate.DriftError{Versions: c.Modified}).Error()
+		check.Hint = "Install the jobtracker release that applied these migrations, or restore the schema from a backup"
+	case len(c.Pending) > 0:
+		check.Status, check.Detail = Fail, "pending migrations: "+strings.Join(c.Pending, ", ")
+		check.Hint = "Run `jobtracker migrate` or pass --auto-migrate"
+	case len(c.Unknown) > 0:
+		check.Status, check.Detail = Warn, "migrations unknown to this version of jobtracker: "+strings.Join(c.Unknown, ", ")
+		check.Hint = "Upgrade jobtracker"
+	default:
+		check.Detail = fmt.Sprintf("%d migrations applied, schema is up to date", len(c.Applied))
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package doctor
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/config"
+	"github.com/spolivin/jobtracker/v2/internal/db/migrate"
+)
+
+// useConfigDir points the config directory to a temporary one
+func useConfigDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv(config.ProfileEnv, "")
+	t.Setenv(config.DatabaseURLEnv, "")
+	return dir
+}
+
+// statuses maps the names of checks to their outcomes
+func statuses(checks []Check) map[string]Status {
+	m := make(map[string]Status, len(checks))
+	for _, check := range checks {
+		m[check.Name] = check.Status
+	}
+	return m
+}
+
+func TestRunSQLite(t *testing.T) {
+	dir := useConfigDir(t)
+	ctx := context.Background()
+
+	checks := Run(ctx, Options{})
+	if got := statuses(checks); got["Config file"] != Fail || got["Profile"] != Skip || Failures(checks) == 0 {
+		t.Fatalf("Run() without config = %v, want failed config file and skipped profile", got)
+	}
+
+	cfg := &config.ConnectionConfig{Driver: config.DriverSQLite, DBPath: filepath.Join(dir, "jobs.db")}
+	if _, err := config.SaveConfig("", cfg); err != nil {
+		t.Fatal(err)
+	}
+	got := statuses(Run(ctx, Options{}))
+	want := map[string]Status{
+		"Config file":        Pass,
+		"Profile":            Pass,
+		"DNS resolution":     Skip,
+		"TCP connection":     Skip,
+		"Authentication":     Pass,
+		"Server version":     Pass,
+		"TLS":                Skip,
+		"Applications table": Fail,
+		"Migrations":         Skip,
+	}
+	for name, status := range want {
+		if got[name] != status {
+			t.Errorf("Run() before migrating: %s = %s, want %s", name, got[name], status)
+		}
+	}
+
+	conn, err := db.Connect(ctx, cfg, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := migrate.Run(ctx, conn); err != nil {
+		t.Fatal(err)
+	}
+	checks = Run(ctx, Options{})
+	if Failures(checks) > 0 {
+		t.Errorf("Run() after migrating failed: %+v", checks)
+	}
+}
+
+func TestRunUnreachable(t *testing.T) {
+	useConfigDir(t)
+	// A port nothing listens on anymore
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	cfg := &config.ConnectionConfig{DBHost: "127.0.0.1", DBPort: port, DBUser: "app", DBName: "jobs", ConnectTimeout: "1s"}
+	if _, err := config.SaveConfig("", cfg); err != nil {
+		t.Fatal(err)
+	}
+	password := func(*config.ConnectionConfig) (string, error) {
+		t.Error("password requested for an unreachable server")
+		return "", nil
+	}
+	checks := Run(context.Background(), Options{Password: password})
+	got := statuses(checks)
+	if got["DNS resolution"] != Pass || got["TCP connection"] != Fail || got["Authentication"] != Skip {
+		t.Errorf("Run() = %v, want failed TCP connection and skipped authentication", got)
+	}
+	for _, check := range checks {
+		if check.Status == Fail && !strings.Contains(check.Hint, "127.0.0.1:"+strconv.Itoa(port)) {
+			t.Errorf("hint of %s = %q, want the server address", check.Name, check.Hint)
+		}
+	}
+}
+
+func TestConnectHint(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"wrong password", &pq.Error{Code: "28P01"}, "password from DB_PASS was rejected"},
+		{"pg_hba", &pq.Error{Code: "28000"}, "pg_hba.conf"},
+		{"missing database", &pq.Error{Code: "3D000"}, "does not exist"},
+		{"timeout", context.DeadlineExceeded, "connect_timeout"},
+		{"no TLS", errors.New("pq: SSL is not enabled on the server"), "sslmode"},
+		{"certificate", errors.New("x509: certificate signed by unknown authority"), "sslrootcert"},
+		{"other", errors.New("EOF"), "jobtracker config"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := connectHint(tt.err, config.SourceDBPass); !strings.Contains(got, tt.want) {
+				t.Errorf("connectHint() = %q, want it to contain %q", got, tt.want)
+			}
+		})
+	}
+}
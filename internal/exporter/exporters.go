@@ -6,7 +6,11 @@ package exporter
 import (
 	"encoding/csv"
 	"encoding/json"
+	"io"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spolivin/jobtracker/v2/internal/db"
 )
@@ -25,6 +29,39 @@ func ExportToJson(data []db.JobApplication, filename string) error {
 
 // ExportToCsv exports job application data to a CSV file.
 func ExportToCsv(data []db.JobApplication, filename string) error {
+	// Creating CSV file
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return WriteCsv(file, data)
+}
+
+// WriteCsv writes job application data as CSV, in the format of ExportToCsv.
+func WriteCsv(w io.Writer, data []db.JobApplication) error {
+	// Creating a CSV writer
+	writer := csv.NewWriter(w)
+
+	// Writing header
+	var headerColumns = []string{"ID", "Company", "Position", "Status", "CreatedAt", "UpdatedAt", "Tags"}
+	if err := writer.Write(headerColumns); err != nil {
+		return err
+	}
+
+	// Writing job entries, tags being joined with commas
+	for _, row := range data {
+		row := append(row.ConvertToStringSlice(), strings.Join(row.Tags, ","))
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportNotesToCsv exports notes of job applications to a CSV file.
+func ExportNotesToCsv(notes []db.Note, filename string) error {
 	// Creating CSV file
 	file, err := os.Create(filename)
 	if err != nil {
@@ -36,14 +73,20 @@ func ExportToCsv(data []db.JobApplication, filename string) error {
 	defer writer.Flush()
 
 	// Writing header
-	var headerColumns = []string{"ID", "Company", "Position", "Status", "CreatedAt", "UpdatedAt"}
+	var headerColumns = []string{"ID", "ApplicationID", "Content", "CreatedAt", "UpdatedAt"}
 	if err := writer.Write(headerColumns); err != nil {
 		return err
 	}
 
-	// Writing job entries
-	for _, row := range data {
-		row := row.ConvertToStringSlice()
+	// Writing note entries
+	for _, note := range notes {
+		row := []string{
+			strconv.Itoa(note.ID),
+			strconv.Itoa(note.ApplicationID),
+			note.Content,
+			note.CreatedAt.Format(time.RFC3339),
+			note.UpdatedAt.Format(time.RFC3339),
+		}
 		if err := writer.Write(row); err != nil {
 			return err
 		}
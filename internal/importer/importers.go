@@ -0,0 +1,328 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+// headerColumns is the CSV header written by exporter.ExportToCsv
+var headerColumns = []string{"ID", "Company", "Position", "Status", "CreatedAt", "UpdatedAt", "Tags"}
+
+// legacyHeaderColumns is the CSV header of files exported before the Tags column was added
+var legacyHeaderColumns = headerColumns[:len(headerColumns)-1]
+
+// notesHeaderColumns is the CSV header written by exporter.ExportNotesToCsv
+var notesHeaderColumns = []string{"ID", "ApplicationID", "Content", "CreatedAt", "UpdatedAt"}
+
+// Record is a job application read from an import file along with its row number.
+type Record struct {
+	Row         int
+	Application db.JobApplication
+}
+
+// RowError describes a problem with a single row of an import file.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// ImportFromCsv reads job applications from a CSV file in the format written by the exporter.
+// Rows are numbered by their line in the file, the header being row 1.
+// Notes are read from the notes file exported alongside (see NotesFilename), if there is one,
+// and attached to the applications by their IDs in the file.
+func ImportFromCsv(filename string) ([]Record, []RowError, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+
+	// Checking header
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("CSV file is empty")
+		}
+		return nil, nil, err
+	}
+	columns := headerColumns
+	if checkHeader(header, legacyHeaderColumns) == nil {
+		columns = legacyHeaderColumns
+	} else if err := checkHeader(header, headerColumns); err != nil {
+		return nil, nil, err
+	}
+
+	// Reading job entries
+	var records []Record
+	var rowErrors []RowError
+	for row := 2; ; row++ {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, RowError{Row: row, Err: parseErr.Err})
+				continue
+			}
+			return nil, nil, err
+		}
+		app, err := parseCsvRow(fields, len(columns))
+		if err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		records = append(records, Record{Row: row, Application: app})
+	}
+
+	notes, err := importNotesFromCsv(NotesFilename(filename))
+	if err != nil {
+		return nil, nil, err
+	}
+	attachNotes(records, notes)
+	return records, rowErrors, nil
+}
+
+// NotesFilename returns the name of the notes file the exporter writes alongside a CSV file,
+// e.g. "data_notes.csv" for "data.csv".
+func NotesFilename(filename string) string {
+	ext := filepath.Ext(filename)
+	return strings.TrimSuffix(filename, ext) + "_notes" + ext
+}
+
+// importNotesFromCsv reads notes from a CSV file in the format written by exporter.ExportNotesToCsv.
+// A missing file has no notes.
+func importNotesFromCsv(filename string) ([]db.Note, error) {
+	file, err := os.Open(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if err := checkHeader(header, notesHeaderColumns); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	var notes []db.Note
+	for row := 2; ; row++ {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", filename, err)
+		}
+		note, err := parseNoteRow(fields)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %w", filename, row, err)
+		}
+		notes = append(notes, note)
+	}
+	return notes, nil
+}
+
+// parseNoteRow converts the fields of a CSV row of the notes file to a note.
+func parseNoteRow(fields []string) (db.Note, error) {
+	var note db.Note
+	var err error
+	if note.ID, err = strconv.Atoi(strings.TrimSpace(fields[0])); err != nil {
+		return note, fmt.Errorf("invalid ID: %q", fields[0])
+	}
+	if note.ApplicationID, err = strconv.Atoi(strings.TrimSpace(fields[1])); err != nil {
+		return note, fmt.Errorf("invalid ApplicationID: %q", fields[1])
+	}
+	note.Content = fields[2]
+	if note.CreatedAt, err = parseTime(fields[3]); err != nil {
+		return note, fmt.Errorf("invalid CreatedAt: %w", err)
+	}
+	if note.UpdatedAt, err = parseTime(fields[4]); err != nil {
+		return note, fmt.Errorf("invalid UpdatedAt: %w", err)
+	}
+	return note, nil
+}
+
+// attachNotes assigns notes to the records of the applications they belong to.
+// Notes of applications missing from the records are dropped.
+func attachNotes(records []Record, notes []db.Note) {
+	index := make(map[int]int, len(records))
+	for i, rec := range records {
+		if rec.Application.ID > 0 {
+			index[rec.Application.ID] = i
+		}
+	}
+	for _, note := range notes {
+		if i, ok := index[note.ApplicationID]; ok {
+			records[i].Application.Notes = append(records[i].Application.Notes, note)
+		}
+	}
+}
+
+// checkHeader checks that a CSV header lists the expected columns, ignoring case and a byte order mark.
+func checkHeader(header, columns []string) error {
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	if len(header) != len(columns) {
+		return fmt.Errorf("unexpected CSV header: %v (expected: %s)", header, strings.Join(columns, ","))
+	}
+	for i, col := range columns {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), col) {
+			return fmt.Errorf("unexpected CSV header: %v (expected: %s)", header, strings.Join(columns, ","))
+		}
+	}
+	return nil
+}
+
+// parseCsvRow converts the fields of a CSV row with the given number of columns to a job application.
+func parseCsvRow(fields []string, columns int) (db.JobApplication, error) {
+	var app db.JobApplication
+	if len(fields) != columns {
+		return app, fmt.Errorf("expected %d fields, got %d", columns, len(fields))
+	}
+	if id := strings.TrimSpace(fields[0]); id != "" {
+		v, err := strconv.Atoi(id)
+		if err != nil {
+			return app, fmt.Errorf("invalid ID: %q", fields[0])
+		}
+		app.ID = v
+	}
+	app.Company = fields[1]
+	app.Position = fields[2]
+	app.Status = fields[3]
+	var err error
+	if app.CreatedAt, err = parseTime(fields[4]); err != nil {
+		return app, fmt.Errorf("invalid CreatedAt: %w", err)
+	}
+	if app.UpdatedAt, err = parseTime(fields[5]); err != nil {
+		return app, fmt.Errorf("invalid UpdatedAt: %w", err)
+	}
+	if columns > 6 {
+		for _, tag := range strings.Split(fields[6], ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				app.Tags = append(app.Tags, tag)
+			}
+		}
+	}
+	return app, validateRecord(app)
+}
+
+// parseTime parses an RFC 3339 timestamp, treating an empty value as unset.
+func parseTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// ImportFromJson reads job applications from a JSON file in the format written by the exporter.
+// Rows are numbered by their position in the top-level array, starting from 1.
+func ImportFromJson(filename string) ([]Record, []RowError, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, nil, fmt.Errorf("invalid JSON file: %w", err)
+	}
+
+	var records []Record
+	var rowErrors []RowError
+	for i, item := range items {
+		row := i + 1
+		var app db.JobApplication
+		if err := json.Unmarshal(item, &app); err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		if err := validateRecord(app); err != nil {
+			rowErrors = append(rowErrors, RowError{Row: row, Err: err})
+			continue
+		}
+		records = append(records, Record{Row: row, Application: app})
+	}
+	return records, rowErrors, nil
+}
+
+// validateRecord checks the fields required to store a job application.
+func validateRecord(app db.JobApplication) error {
+	if app.ID < 0 {
+		return fmt.Errorf("invalid ID: %d", app.ID)
+	}
+	if strings.TrimSpace(app.Company) == "" {
+		return fmt.Errorf("company cannot be empty")
+	}
+	if strings.TrimSpace(app.Position) == "" {
+		return fmt.Errorf("position cannot be empty")
+	}
+	if _, err := db.NormalizeTags(app.Tags); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Validate checks records against the status workflow and, when IDs are kept,
+// against IDs already present in the database or repeated in the file.
+// Valid records are returned with their statuses normalized.
+func Validate(records []Record, workflow *db.Workflow, force, keepIDs bool, existingIDs map[int]bool) ([]Record, []RowError) {
+	var valid []Record
+	var rowErrors []RowError
+	seen := make(map[int]int)
+	for _, rec := range records {
+		if !force {
+			status, err := workflow.NormalizeStatus(rec.Application.Status)
+			if err != nil {
+				rowErrors = append(rowErrors, RowError{Row: rec.Row, Err: err})
+				continue
+			}
+			rec.Application.Status = status
+		}
+		if keepIDs && rec.Application.ID > 0 {
+			id := rec.Application.ID
+			if existingIDs[id] {
+				rowErrors = append(rowErrors, RowError{Row: rec.Row, Err: fmt.Errorf("application with ID %d already exists", id)})
+				continue
+			}
+			if prev, ok := seen[id]; ok {
+				rowErrors = append(rowErrors, RowError{Row: rec.Row, Err: fmt.Errorf("duplicate ID %d (first seen in row %d)", id, prev)})
+				continue
+			}
+			seen[id] = rec.Row
+		}
+		valid = append(valid, rec)
+	}
+	return valid, rowErrors
+}
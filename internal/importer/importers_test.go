@@ -0,0 +1,276 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/exporter"
+)
+
+func sampleApplications() []db.JobApplication {
+	return []db.JobApplication{
+		{
+			ID:        1,
+			Company:   "Google",
+			Position:  "Software Engineer",
+			Status:    "Applied",
+			CreatedAt: time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2026, 1, 16, 10, 30, 0, 0, time.UTC),
+			Tags:      []string{"remote", "urgent"},
+		},
+		{
+			ID:        7,
+			Company:   "Company & Co., Ltd.",
+			Position:  "Senior \"Staff\" Engineer",
+			Status:    "Interview",
+			CreatedAt: time.Date(2026, 2, 1, 14, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2026, 2, 3, 9, 15, 0, 0, time.UTC),
+		},
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func applications(records []Record) []db.JobApplication {
+	var apps []db.JobApplication
+	for _, rec := range records {
+		apps = append(apps, rec.Application)
+	}
+	return apps
+}
+
+func TestImportFromCsvRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := exporter.ExportToCsv(sampleApplications(), path); err != nil {
+		t.Fatal(err)
+	}
+
+	records, rowErrors, err := ImportFromCsv(path)
+	if err != nil {
+		t.Fatalf("ImportFromCsv() error = %v", err)
+	}
+	if len(rowErrors) != 0 {
+		t.Errorf("ImportFromCsv() row errors = %v, want none", rowErrors)
+	}
+	if got := applications(records); !reflect.DeepEqual(got, sampleApplications()) {
+		t.Errorf("ImportFromCsv() = %+v, want %+v", got, sampleApplications())
+	}
+	if records[0].Row != 2 || records[1].Row != 3 {
+		t.Errorf("row numbers = %d, %d, want 2, 3", records[0].Row, records[1].Row)
+	}
+}
+
+func TestImportFromCsvNotes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.csv")
+	if err := exporter.ExportToCsv(sampleApplications(), path); err != nil {
+		t.Fatal(err)
+	}
+	notes := []db.Note{
+		{ID: 3, ApplicationID: 7, Content: "Ask about the team, \"remote\" policy", CreatedAt: time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC)},
+		{ID: 4, ApplicationID: 42, Content: "Belongs to no exported application"},
+	}
+	if got := NotesFilename(path); got != filepath.Join(filepath.Dir(path), "data_notes.csv") {
+		t.Fatalf("NotesFilename() = %q", got)
+	}
+	if err := exporter.ExportNotesToCsv(notes, NotesFilename(path)); err != nil {
+		t.Fatal(err)
+	}
+
+	records, _, err := ImportFromCsv(path)
+	if err != nil {
+		t.Fatalf("ImportFromCsv() error = %v", err)
+	}
+	if len(records[0].Application.Notes) != 0 {
+		t.Errorf("notes of application 1 = %+v, want none", records[0].Application.Notes)
+	}
+	if got := records[1].Application.Notes; len(got) != 1 || !reflect.DeepEqual(got[0], notes[0]) {
+		t.Errorf("notes of application 7 = %+v, want %+v", got, notes[:1])
+	}
+
+	// A malformed notes file fails the import rather than losing the notes
+	if err := os.WriteFile(NotesFilename(path), []byte("ID,ApplicationID,Content,CreatedAt,UpdatedAt\nx,7,Note,,\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := ImportFromCsv(path); err == nil || !strings.Contains(err.Error(), "row 2: invalid ID") {
+		t.Errorf("ImportFromCsv() with malformed notes error = %v", err)
+	}
+}
+
+func TestImportFromJsonRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data.json")
+	if err := exporter.ExportToJson(sampleApplications(), path); err != nil {
+		t.Fatal(err)
+	}
+
+	records, rowErrors, err := ImportFromJson(path)
+	if err != nil {
+		t.Fatalf("ImportFromJson() error = %v", err)
+	}
+	if len(rowErrors) != 0 {
+		t.Errorf("ImportFromJson() row errors = %v, want none", rowErrors)
+	}
+	if got := applications(records); !reflect.DeepEqual(got, sampleApplications()) {
+		t.Errorf("ImportFromJson() = %+v, want %+v", got, sampleApplications())
+	}
+}
+
+func TestImportFromCsvRowErrors(t *testing.T) {
+	// Files exported before the Tags column was added are still accepted
+	path := writeFile(t, "data.csv", strings.Join([]string{
+		"ID,Company,Position,Status,CreatedAt,UpdatedAt",
+		"1,Google,Engineer,Applied,2026-01-15T10:30:00Z,2026-01-15T10:30:00Z",
+		"abc,Apple,Engineer,Applied,,",
+		"3,,Engineer,Applied,,",
+		"4,Tesla,Engineer,Applied,yesterday,",
+		"5,Amazon,Engineer",
+		",Netflix,Engineer,Applied,,",
+	}, "\n"))
+
+	records, rowErrors, err := ImportFromCsv(path)
+	if err != nil {
+		t.Fatalf("ImportFromCsv() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("ImportFromCsv() returned %d records, want 2", len(records))
+	}
+	var rows []int
+	for _, rowErr := range rowErrors {
+		rows = append(rows, rowErr.Row)
+	}
+	if want := []int{3, 4, 5, 6}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("row errors in rows %v, want %v (errors: %v)", rows, want, rowErrors)
+	}
+	if !strings.HasPrefix(rowErrors[0].Error(), "row 3: ") {
+		t.Errorf("RowError.Error() = %q, want prefix %q", rowErrors[0].Error(), "row 3: ")
+	}
+}
+
+func TestImportFromCsvTags(t *testing.T) {
+	path := writeFile(t, "data.csv", strings.Join([]string{
+		"ID,Company,Position,Status,CreatedAt,UpdatedAt,Tags",
+		`1,Google,Engineer,Applied,,,"remote, urgent"`,
+		"2,Apple,Engineer,Applied,,,",
+		`3,Tesla,Engineer,Applied,,,"on site"`,
+		"4,Amazon,Engineer,Applied,,",
+	}, "\n"))
+
+	records, rowErrors, err := ImportFromCsv(path)
+	if err != nil {
+		t.Fatalf("ImportFromCsv() error = %v", err)
+	}
+	if len(records) != 2 || !reflect.DeepEqual(records[0].Application.Tags, []string{"remote", "urgent"}) || records[1].Application.Tags != nil {
+		t.Errorf("ImportFromCsv() = %+v, want Google tagged remote and urgent and Apple untagged", applications(records))
+	}
+	if len(rowErrors) != 2 || rowErrors[0].Row != 4 || rowErrors[1].Row != 5 {
+		t.Errorf("row errors = %v, want rows 4 and 5", rowErrors)
+	}
+}
+
+func TestImportFromCsvInvalidHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"empty file", ""},
+		{"wrong columns", "Company,Position,Status\nGoogle,Engineer,Applied"},
+		{"wrong order", "ID,Position,Company,Status,CreatedAt,UpdatedAt"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, "data.csv", tt.content)
+			if _, _, err := ImportFromCsv(path); err == nil {
+				t.Error("ImportFromCsv() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestImportFromJsonRowErrors(t *testing.T) {
+	path := writeFile(t, "data.json", `[
+		{"id": 1, "company": "Google", "position": "Engineer", "status": "Applied"},
+		{"id": "two", "company": "Apple", "position": "Engineer", "status": "Applied"},
+		{"company": "Tesla", "position": "", "status": "Applied"},
+		{"company": "Amazon", "position": "Engineer", "status": "Applied"}
+	]`)
+
+	records, rowErrors, err := ImportFromJson(path)
+	if err != nil {
+		t.Fatalf("ImportFromJson() error = %v", err)
+	}
+	if len(records) != 2 || len(rowErrors) != 2 {
+		t.Fatalf("ImportFromJson() = %d records, %d errors, want 2 and 2", len(records), len(rowErrors))
+	}
+	if rowErrors[0].Row != 2 || rowErrors[1].Row != 3 {
+		t.Errorf("row errors in rows %d, %d, want 2, 3", rowErrors[0].Row, rowErrors[1].Row)
+	}
+}
+
+func TestImportFromJsonInvalidFile(t *testing.T) {
+	path := writeFile(t, "data.json", `{"id": 1}`)
+	if _, _, err := ImportFromJson(path); err == nil {
+		t.Error("ImportFromJson() expected error for non-array JSON, got nil")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	records := []Record{
+		{Row: 2, Application: db.JobApplication{ID: 1, Company: "A", Position: "P", Status: "applied"}},
+		{Row: 3, Application: db.JobApplication{ID: 2, Company: "B", Position: "P", Status: "Ghosted"}},
+		{Row: 4, Application: db.JobApplication{ID: 3, Company: "C", Position: "P", Status: "Offer"}},
+		{Row: 5, Application: db.JobApplication{ID: 1, Company: "D", Position: "P", Status: "Offer"}},
+	}
+	workflow := db.DefaultWorkflow()
+
+	tests := []struct {
+		name        string
+		force       bool
+		keepIDs     bool
+		existingIDs map[int]bool
+		wantRows    []int
+		wantErrRows []int
+	}{
+		{"new IDs", false, false, nil, []int{2, 4, 5}, []int{3}},
+		{"forced statuses", true, false, nil, []int{2, 3, 4, 5}, nil},
+		{"kept IDs", false, true, map[int]bool{3: true}, []int{2}, []int{3, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, rowErrors := Validate(records, workflow, tt.force, tt.keepIDs, tt.existingIDs)
+			var rows, errRows []int
+			for _, rec := range valid {
+				rows = append(rows, rec.Row)
+			}
+			for _, rowErr := range rowErrors {
+				errRows = append(errRows, rowErr.Row)
+			}
+			if !reflect.DeepEqual(rows, tt.wantRows) {
+				t.Errorf("Validate() valid rows = %v, want %v", rows, tt.wantRows)
+			}
+			if !reflect.DeepEqual(errRows, tt.wantErrRows) {
+				t.Errorf("Validate() error rows = %v, want %v", errRows, tt.wantErrRows)
+			}
+		})
+	}
+
+	valid, _ := Validate(records[:1], workflow, false, false, nil)
+	if valid[0].Application.Status != "Applied" {
+		t.Errorf("Validate() status = %q, want normalized %q", valid[0].Application.Status, "Applied")
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// webFS holds the dashboard, a static page working against the API
+//
+//go:embed web
+var webFS embed.FS
+
+// contentSecurityPolicy keeps the dashboard to its own assets, so it never reaches out to other hosts
+const contentSecurityPolicy = "default-src 'none'; script-src 'self'; style-src 'self'; img-src 'self' data:; connect-src 'self'; form-action 'self'; frame-ancestors 'none'; base-uri 'none'"
+
+// dashboard serves the embedded dashboard. The page itself is public; it asks for the API token to load data.
+func dashboard() http.Handler {
+	assets, err := fs.Sub(webFS, "web")
+	if err != nil {
+		panic(err)
+	}
+	files := http.FileServerFS(assets)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Security-Policy", contentSecurityPolicy)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		files.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,389 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/exporter"
+	"github.com/spolivin/jobtracker/v2/internal/stats"
+)
+
+// applicationList is the response of the endpoints listing applications
+type applicationList struct {
+	Applications []db.JobApplication `json:"applications"`
+	// Total is the number of applications matching the filters, across all pages
+	Total int `json:"total"`
+	// Next is the cursor of the next page, if there is one
+	Next string `json:"next,omitempty"`
+}
+
+// applicationCreate is the request body creating an application
+type applicationCreate struct {
+	Company  string   `json:"company"`
+	Position string   `json:"position"`
+	Status   string   `json:"status"`
+	Tags     []string `json:"tags"`
+	Force    bool     `json:"force"`
+}
+
+// applicationUpdate is the request body updating an application, fields left out are kept
+type applicationUpdate struct {
+	Company    *string  `json:"company"`
+	Position   *string  `json:"position"`
+	Status     *string  `json:"status"`
+	AddTags    []string `json:"add_tags"`
+	RemoveTags []string `json:"remove_tags"`
+	Force      bool     `json:"force"`
+}
+
+// listApplications lists the applications matching the filters, one page at a time if a limit is set.
+func (s *Server) listApplications(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	filter, err := parseFilter(q)
+	if err != nil {
+		return badRequest(err)
+	}
+	sortBy := q.Get("sort")
+	if sortBy != "" {
+		if err := db.ValidateColumnName(sortBy); err != nil {
+			return badRequest(err)
+		}
+	}
+	descending, err := parseBool(q, "desc")
+	if err != nil {
+		return badRequest(err)
+	}
+	page, err := parsePage(q)
+	if err != nil {
+		return badRequest(err)
+	}
+
+	apps, more, err := s.store.ReadPage(r.Context(), sortBy, descending, filter, page)
+	if err != nil {
+		return err
+	}
+	total, err := s.store.Count(r.Context(), filter)
+	if err != nil {
+		return err
+	}
+	resp := applicationList{Applications: apps, Total: total}
+	if apps == nil {
+		resp.Applications = []db.JobApplication{}
+	}
+	if more {
+		resp.Next = db.CursorOf(apps[len(apps)-1], sortBy).String()
+	}
+	return writeJSON(w, http.StatusOK, resp)
+}
+
+// createApplication adds an application, in the first status of the workflow unless one is given.
+func (s *Server) createApplication(w http.ResponseWriter, r *http.Request) error {
+	var req applicationCreate
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	if strings.TrimSpace(req.Company) == "" || strings.TrimSpace(req.Position) == "" {
+		return badRequest(errors.New("company and position are required"))
+	}
+	if req.Status == "" {
+		if statuses := s.store.Workflow().Statuses(); len(statuses) > 0 {
+			req.Status = statuses[0]
+		}
+	}
+	if !req.Force {
+		if _, err := s.store.Workflow().NormalizeStatus(req.Status); err != nil {
+			return badRequest(err)
+		}
+	}
+	if _, err := db.NormalizeTags(req.Tags); err != nil {
+		return badRequest(err)
+	}
+
+	id, err := s.store.Add(r.Context(), req.Company, req.Position, req.Status, req.Tags, req.Force)
+	if err != nil {
+		return err
+	}
+	app, err := s.store.Get(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/applications/%d", id))
+	return writeJSON(w, http.StatusCreated, app)
+}
+
+// getApplication returns an application along with its notes and status history.
+func (s *Server) getApplication(w http.ResponseWriter, r *http.Request) error {
+	id, err := applicationID(r)
+	if err != nil {
+		return err
+	}
+	app, err := s.store.Get(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if app.Notes, err = s.store.ReadNotes(r.Context(), id); err != nil {
+		return err
+	}
+	history, err := s.store.History(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, db.ApplicationDetails{JobApplication: app, History: history})
+}
+
+// updateApplication changes the fields and tags of an application.
+// Status changes follow the workflow unless forced.
+func (s *Server) updateApplication(w http.ResponseWriter, r *http.Request) error {
+	id, err := applicationID(r)
+	if err != nil {
+		return err
+	}
+	var req applicationUpdate
+	if err := readJSON(r, &req); err != nil {
+		return err
+	}
+	fields := make(map[string]string)
+	for name, value := range map[string]*string{"company": req.Company, "position": req.Position, "status": req.Status} {
+		if value == nil {
+			continue
+		}
+		if strings.TrimSpace(*value) == "" {
+			return badRequest(fmt.Errorf("%s cannot be empty", name))
+		}
+		fields[name] = *value
+	}
+	if len(fields) == 0 && len(req.AddTags) == 0 && len(req.RemoveTags) == 0 {
+		return badRequest(errors.New("no fields specified to update"))
+	}
+
+	for _, tags := range [][]string{req.AddTags, req.RemoveTags} {
+		if _, err := db.NormalizeTags(tags); err != nil {
+			return badRequest(err)
+		}
+	}
+
+	if err := s.store.UpdateApplication(r.Context(), id, fields, req.AddTags, req.RemoveTags, req.Force); err != nil {
+		var transitionErr *db.TransitionError
+		if errors.As(err, &transitionErr) {
+			return badRequest(err)
+		}
+		return err
+	}
+	app, err := s.store.Get(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, app)
+}
+
+// deleteApplication deletes an application along with its notes and history.
+func (s *Server) deleteApplication(w http.ResponseWriter, r *http.Request) error {
+	id, err := applicationID(r)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := s.store.Delete(r.Context(), id)
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return notFound()
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// search finds the applications matching a keyword.
+func (s *Server) search(w http.ResponseWriter, r *http.Request) error {
+	keyword := r.URL.Query().Get("q")
+	if strings.TrimSpace(keyword) == "" {
+		return badRequest(errors.New("query parameter q is required"))
+	}
+	apps, err := s.store.Search(r.Context(), keyword)
+	if err != nil {
+		return err
+	}
+	if apps == nil {
+		apps = []db.JobApplication{}
+	}
+	return writeJSON(w, http.StatusOK, applicationList{Applications: apps, Total: len(apps)})
+}
+
+// export downloads the applications matching the filters in the format of the export command:
+// JSON with the notes nested into their applications, or CSV.
+func (s *Server) export(w http.ResponseWriter, r *http.Request) error {
+	q := r.URL.Query()
+	filter, err := parseFilter(q)
+	if err != nil {
+		return badRequest(err)
+	}
+	format := q.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		return badRequest(fmt.Errorf("unsupported export format: %s", format))
+	}
+
+	apps, err := s.store.Read(r.Context(), "", false, filter)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Disposition", `attachment; filename="exported_data.`+format+`"`)
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv")
+		return exporter.WriteCsv(w, apps)
+	}
+	notes, err := s.store.ReadAllNotes(r.Context())
+	if err != nil {
+		return err
+	}
+	db.AttachNotes(apps, notes)
+	if apps == nil {
+		apps = []db.JobApplication{}
+	}
+	return writeJSON(w, http.StatusOK, apps)
+}
+
+// workflowStatuses is the response describing the status workflow
+type workflowStatuses struct {
+	Statuses []string `json:"statuses"`
+	// Transitions lists the statuses reachable from every status
+	Transitions map[string][]string `json:"transitions"`
+}
+
+// workflow describes the configured status workflow, e.g. for status pickers.
+func (s *Server) workflow(w http.ResponseWriter, r *http.Request) error {
+	wf := s.store.Workflow()
+	resp := workflowStatuses{Statuses: wf.Statuses(), Transitions: make(map[string][]string)}
+	for _, status := range resp.Statuses {
+		next := wf.Next(status)
+		if next == nil {
+			next = []string{}
+		}
+		resp.Transitions[status] = next
+	}
+	return writeJSON(w, http.StatusOK, resp)
+}
+
+// statistics computes the pipeline analytics of the stats command over the applications matching the filters.
+func (s *Server) statistics(w http.ResponseWriter, r *http.Request) error {
+	filter, err := parseFilter(r.URL.Query())
+	if err != nil {
+		return badRequest(err)
+	}
+	apps, err := s.store.Read(r.Context(), "", false, filter)
+	if err != nil {
+		return err
+	}
+	history, err := s.store.ReadHistory(r.Context())
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, http.StatusOK, stats.Compute(apps, history, s.store.Workflow()))
+}
+
+// applicationID parses the ID of the application in the path.
+func applicationID(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		return 0, badRequest(fmt.Errorf("invalid application ID: %q", r.PathValue("id")))
+	}
+	return id, nil
+}
+
+// parseFilter compiles the query parameters into a store filter, like the filter flags of the CLI.
+// Statuses and tags are repeatable or comma-separated.
+func parseFilter(q url.Values) (db.Filter, error) {
+	filter := db.Filter{
+		Statuses: splitValues(q["status"]),
+		Company:  q.Get("company"),
+		Tags:     splitValues(q["tag"]),
+	}
+	var err error
+	if filter.AnyTag, err = parseBool(q, "any"); err != nil {
+		return filter, err
+	}
+	if v := q.Get("since"); v != "" {
+		if filter.Since, err = db.ParseDate(v, false); err != nil {
+			return filter, err
+		}
+	}
+	if v := q.Get("until"); v != "" {
+		if filter.Until, err = db.ParseDate(v, true); err != nil {
+			return filter, err
+		}
+	}
+	if v := q.Get("updated_since"); v != "" {
+		if filter.UpdatedSince, err = db.ParseDate(v, false); err != nil {
+			return filter, err
+		}
+	}
+	if v := q.Get("stale"); v != "" {
+		if filter.Stale, err = db.ParseDuration(v); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// parsePage reads the pagination parameters.
+func parsePage(q url.Values) (db.Page, error) {
+	var page db.Page
+	for name, value := range map[string]*int{"limit": &page.Limit, "offset": &page.Offset} {
+		v := q.Get(name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return page, fmt.Errorf("%s must be a non-negative integer", name)
+		}
+		*value = n
+	}
+	if after := q.Get("after"); after != "" {
+		if page.Offset > 0 {
+			return page, errors.New("after cannot be used together with offset")
+		}
+		cursor, err := db.ParseCursor(after)
+		if err != nil {
+			return page, err
+		}
+		page.After = &cursor
+	}
+	return page, nil
+}
+
+// parseBool reads a boolean query parameter, false if missing.
+func parseBool(q url.Values, name string) (bool, error) {
+	v := q.Get(name)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", name)
+	}
+	return b, nil
+}
+
+// splitValues splits repeated and comma-separated values.
+func splitValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,212 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+// TokenEnv is the environment variable holding the API token.
+const TokenEnv = "JOBTRACKER_API_TOKEN"
+
+// shutdownTimeout is how long in-flight requests get to finish on shutdown
+const shutdownTimeout = 10 * time.Second
+
+// maxBodySize limits the size of request bodies
+const maxBodySize = 1 << 20
+
+//go:embed openapi.json
+var openAPI []byte
+
+// Options configures the API server.
+type Options struct {
+	// Token is the API token expected in the Authorization header of every request.
+	Token string
+	// Logger receives a line per request (nothing is logged if nil).
+	Logger *log.Logger
+}
+
+// Server serves the REST API over a store.
+type Server struct {
+	store  db.Store
+	token  string
+	logger *log.Logger
+	mux    *http.ServeMux
+}
+
+// New creates the API server over a store, whose workflow validates status changes.
+func New(store db.Store, opts Options) *Server {
+	s := &Server{store: store, token: opts.Token, logger: opts.Logger, mux: http.NewServeMux()}
+	s.routes()
+	return s
+}
+
+// GenerateToken returns a random API token.
+func GenerateToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// routes registers the endpoints of the API.
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /api/v1/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openAPI)
+	})
+	s.handle("GET /api/v1/applications", s.listApplications)
+	s.handle("POST /api/v1/applications", s.createApplication)
+	s.handle("GET /api/v1/applications/{id}", s.getApplication)
+	s.handle("PATCH /api/v1/applications/{id}", s.updateApplication)
+	s.handle("DELETE /api/v1/applications/{id}", s.deleteApplication)
+	s.handle("GET /api/v1/search", s.search)
+	s.handle("GET /api/v1/export", s.export)
+	s.handle("GET /api/v1/workflow", s.workflow)
+	s.handle("GET /api/v1/stats", s.statistics)
+	s.mux.Handle("GET /", dashboard())
+}
+
+// handlerFunc is an endpoint reporting failures as errors, turned into JSON error responses.
+type handlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// handle registers an endpoint requiring the API token.
+func (s *Server) handle(pattern string, h handlerFunc) {
+	s.mux.Handle(pattern, s.authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := h(w, r); err != nil {
+			s.writeError(w, err)
+		}
+	})))
+}
+
+// ServeHTTP logs the request and passes it on to the endpoint.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.mux.ServeHTTP(rec, r)
+	if s.logger != nil {
+		s.logger.Printf("%s %s %d %s", r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Microsecond))
+	}
+}
+
+// statusRecorder remembers the status code of a response for the request log
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// authenticate rejects requests without the API token as a bearer token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="jobtracker"`)
+			s.writeError(w, &apiError{http.StatusUnauthorized, "missing or invalid API token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Serve serves the API on the listener until the context is canceled,
+// then lets in-flight requests finish before returning.
+func Serve(ctx context.Context, ln net.Listener, handler http.Handler) error {
+	srv := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// apiError is a failure reported to the client with a status code.
+type apiError struct {
+	status  int
+	message string
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+// badRequest reports an invalid request.
+func badRequest(err error) error {
+	return &apiError{http.StatusBadRequest, err.Error()}
+}
+
+// notFound reports a missing job application.
+func notFound() error {
+	return &apiError{http.StatusNotFound, db.ErrNotFound.Error()}
+}
+
+// writeError sends an error response. Unexpected errors are logged and hidden from the client.
+func (s *Server) writeError(w http.ResponseWriter, err error) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		if errors.Is(err, db.ErrNotFound) {
+			apiErr = notFound().(*apiError)
+		} else {
+			if s.logger != nil {
+				s.logger.Printf("error: %v", err)
+			}
+			apiErr = &apiError{http.StatusInternalServerError, "internal server error"}
+		}
+	}
+	writeJSON(w, apiErr.status, map[string]string{"error": apiErr.message})
+}
+
+// writeJSON sends a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v any) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// readJSON decodes a JSON request body, rejecting unknown fields.
+func readJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return badRequest(errors.New("invalid request body: " + err.Error()))
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/dbtest"
+)
+
+const testToken = "secret"
+
+// newServer serves the API over a migrated SQLite database holding a few applications
+func newServer(t *testing.T) (*Server, db.Store) {
+	t.Helper()
+	store := dbtest.NewSQLiteStore(t, dbtest.Applications("remote")...)
+	return New(store, Options{Token: testToken}), store
+}
+
+// request sends an authenticated request, decoding the JSON response into out if given
+func request(t *testing.T, s *Server, method, target, body string, out any) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: invalid JSON response %q: %v", method, target, rec.Body.String(), err)
+		}
+	}
+	return rec
+}
+
+func TestAuthentication(t *testing.T) {
+	s, _ := newServer(t)
+	for _, header := range []string{"", "Bearer wrong", "secret"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/applications", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("Authorization %q: status %d, want 401 with a challenge", header, rec.Code)
+		}
+	}
+
+	// The API description is public
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	var doc map[string]any
+	if rec.Code != http.StatusOK || json.Unmarshal(rec.Body.Bytes(), &doc) != nil || doc["openapi"] == nil {
+		t.Errorf("openapi.json: status %d, body %.80q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestApplicationsCRUD(t *testing.T) {
+	s, _ := newServer(t)
+
+	var created db.JobApplication
+	rec := request(t, s, http.MethodPost, "/api/v1/applications", `{"company":"Meta","position":"SRE","tags":["onsite"]}`, &created)
+	if rec.Code != http.StatusCreated || created.ID != 4 || created.Status != "Applied" || rec.Header().Get("Location") != "/api/v1/applications/4" {
+		t.Fatalf("POST: status %d, %+v, Location %q", rec.Code, created, rec.Header().Get("Location"))
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		want   int
+	}{
+		{"missing position", http.MethodPost, "/api/v1/applications", `{"company":"Meta"}`, http.StatusBadRequest},
+		{"unknown status", http.MethodPost, "/api/v1/applications", `{"company":"Meta","position":"SRE","status":"Dreaming"}`, http.StatusBadRequest},
+		{"unknown field", http.MethodPost, "/api/v1/applications", `{"company":"Meta","position":"SRE","salary":1}`, http.StatusBadRequest},
+		{"invalid transition", http.MethodPatch, "/api/v1/applications/4", `{"status":"Offer"}`, http.StatusBadRequest},
+		{"empty update", http.MethodPatch, "/api/v1/applications/4", `{}`, http.StatusBadRequest},
+		{"missing application", http.MethodPatch, "/api/v1/applications/99", `{"company":"X"}`, http.StatusNotFound},
+		{"invalid ID", http.MethodGet, "/api/v1/applications/abc", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp map[string]string
+			rec := request(t, s, tt.method, tt.target, tt.body, &resp)
+			if rec.Code != tt.want || resp["error"] == "" {
+				t.Errorf("status %d, body %v, want %d with an error", rec.Code, resp, tt.want)
+			}
+		})
+	}
+
+	var updated db.JobApplication
+	rec = request(t, s, http.MethodPatch, "/api/v1/applications/4", `{"status":"screening","add_tags":["hot"],"remove_tags":["onsite"]}`, &updated)
+	if rec.Code != http.StatusOK || updated.Status != "Screening" || strings.Join(updated.Tags, ",") != "hot" {
+		t.Errorf("PATCH: status %d, %+v", rec.Code, updated)
+	}
+	rec = request(t, s, http.MethodPatch, "/api/v1/applications/4", `{"status":"Offer","force":true}`, &updated)
+	if rec.Code != http.StatusOK || updated.Status != "Offer" {
+		t.Errorf("forced PATCH: status %d, %+v", rec.Code, updated)
+	}
+
+	var details db.ApplicationDetails
+	request(t, s, http.MethodGet, "/api/v1/applications/4", "", &details)
+	if details.Company != "Meta" || len(details.History) != 3 {
+		t.Errorf("GET: %+v, want the history of three statuses", details)
+	}
+
+	if rec := request(t, s, http.MethodDelete, "/api/v1/applications/4", "", nil); rec.Code != http.StatusNoContent {
+		t.Errorf("DELETE: status %d, want 204", rec.Code)
+	}
+	if rec := request(t, s, http.MethodGet, "/api/v1/applications/4", "", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("GET after DELETE: status %d, want 404", rec.Code)
+	}
+}
+
+func TestListAndSearch(t *testing.T) {
+	s, store := newServer(t)
+	if _, err := store.Update(context.Background(), 2, map[string]string{"status": "Screening"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var list applicationList
+	request(t, s, http.MethodGet, "/api/v1/applications?sort=company&limit=2", "", &list)
+	if list.Total != 3 || len(list.Applications) != 2 || list.Applications[0].Company != "Apple" || list.Next == "" {
+		t.Fatalf("first page = %+v", list)
+	}
+	var next applicationList
+	request(t, s, http.MethodGet, "/api/v1/applications?sort=company&limit=2&after="+list.Next, "", &next)
+	if len(next.Applications) != 1 || next.Applications[0].Company != "Stripe" || next.Next != "" {
+		t.Errorf("second page = %+v", next)
+	}
+	var full applicationList
+	request(t, s, http.MethodGet, "/api/v1/applications?sort=company&limit=3", "", &full)
+	if len(full.Applications) != 3 || full.Next != "" {
+		t.Errorf("exactly full page = %+v, want no next cursor", full)
+	}
+
+	request(t, s, http.MethodGet, "/api/v1/applications?status=applied&status=offer&tag=remote", "", &list)
+	if list.Total != 2 {
+		t.Errorf("filtered list = %+v, want the two applied applications", list)
+	}
+	for _, target := range []string{"/api/v1/applications?sort=salary", "/api/v1/applications?limit=-1", "/api/v1/applications?stale=soon", "/api/v1/search"} {
+		if rec := request(t, s, http.MethodGet, target, "", nil); rec.Code != http.StatusBadRequest {
+			t.Errorf("GET %s: status %d, want 400", target, rec.Code)
+		}
+	}
+
+	request(t, s, http.MethodGet, "/api/v1/search?q=engineer", "", &list)
+	if list.Total != 2 {
+		t.Errorf("search = %+v, want the two engineers", list)
+	}
+}
+
+func TestExport(t *testing.T) {
+	s, store := newServer(t)
+	if _, err := store.AddNote(context.Background(), 1, "Recruiter call"); err != nil {
+		t.Fatal(err)
+	}
+
+	var apps []db.JobApplication
+	request(t, s, http.MethodGet, "/api/v1/export?company=goo", "", &apps)
+	if len(apps) != 1 || len(apps[0].Notes) != 1 {
+		t.Errorf("JSON export = %+v, want Google with its note", apps)
+	}
+
+	rec := request(t, s, http.MethodGet, "/api/v1/export?format=csv", "", nil)
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	if rec.Header().Get("Content-Type") != "text/csv" || len(lines) != 4 || lines[0] != "ID,Company,Position,Status,CreatedAt,UpdatedAt,Tags" || !strings.HasSuffix(lines[1], ",remote") {
+		t.Errorf("CSV export = %q", rec.Body.String())
+	}
+	if rec := request(t, s, http.MethodGet, "/api/v1/export?format=xml", "", nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("XML export: status %d, want 400", rec.Code)
+	}
+}
+
+func TestServeShutdown(t *testing.T) {
+	s, _ := newServer(t)
+	var logs bytes.Buffer
+	s.logger = log.New(&logs, "", 0)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- Serve(ctx, ln, s) }()
+
+	req, _ := http.NewRequest(http.MethodGet, "http://"+ln.Addr().String()+"/api/v1/applications", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request error = %v", err)
+	}
+	resp.Body.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve() error = %v, want a clean shutdown", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve() did not return after the context was canceled")
+	}
+	if !strings.Contains(logs.String(), "GET /api/v1/applications 200") {
+		t.Errorf("request log = %q", logs.String())
+	}
+}
+
+func TestWorkflowAndStats(t *testing.T) {
+	s, store := newServer(t)
+	if _, err := store.Update(context.Background(), 1, map[string]string{"status": "Screening"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	var workflow workflowStatuses
+	request(t, s, http.MethodGet, "/api/v1/workflow", "", &workflow)
+	if workflow.Statuses[0] != "Applied" || workflow.Transitions["Applied"][0] != "Screening" || workflow.Transitions["Offer"] == nil {
+		t.Errorf("workflow = %+v", workflow)
+	}
+
+	var report struct {
+		Total  int `json:"total"`
+		Funnel []struct {
+			Status  string `json:"status"`
+			Reached int    `json:"reached"`
+		} `json:"funnel"`
+	}
+	request(t, s, http.MethodGet, "/api/v1/stats?company=g", "", &report)
+	if report.Total != 1 || report.Funnel[1].Status != "Screening" || report.Funnel[1].Reached != 1 {
+		t.Errorf("stats of Google = %+v", report)
+	}
+}
+
+func TestStatsIgnoreFilteredOutHistory(t *testing.T) {
+	s, store := newServer(t)
+	ctx := context.Background()
+	if _, err := store.Update(ctx, 1, map[string]string{"status": "Screening"}, false); err != nil {
+		t.Fatal(err)
+	}
+	// Apple is left out by the filter, so its time in Applied and Interview must not count
+	for _, status := range []string{"Interview", "Offer"} {
+		if _, err := store.Update(ctx, 2, map[string]string{"status": status}, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var report struct {
+		TimeInStatus []struct {
+			Status  string `json:"status"`
+			Samples int    `json:"samples"`
+		} `json:"time_in_status"`
+	}
+	request(t, s, http.MethodGet, "/api/v1/stats?company=google", "", &report)
+	if len(report.TimeInStatus) != 1 || report.TimeInStatus[0].Status != "Applied" || report.TimeInStatus[0].Samples != 1 {
+		t.Errorf("time_in_status of Google = %+v, want a single sample of Applied", report.TimeInStatus)
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	s, _ := newServer(t)
+	for _, target := range []string{"/", "/app.js", "/style.css"} {
+		// The dashboard loads without a token, and never from other hosts
+		rec := httptest.NewRecorder()
+		s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Content-Security-Policy"), "default-src 'none'") {
+			t.Errorf("GET %s: status %d, CSP %q", target, rec.Code, rec.Header().Get("Content-Security-Policy"))
+		}
+		body := strings.ReplaceAll(rec.Body.String(), "http://www.w3.org/2000/svg", "")
+		if strings.Contains(body, "http://") || strings.Contains(body, "https://") {
+			t.Errorf("GET %s references an external URL", target)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+// Dashboard of jobtracker, working against the REST API served by `jobtracker serve`.
+"use strict";
+
+const tokenKey = "jobtracker.token";
+const pageSize = 50;
+const svgNS = "http://www.w3.org/2000/svg";
+
+const state = {
+  token: localStorage.getItem(tokenKey) || "",
+  workflow: { statuses: [], transitions: {} },
+  sort: "updated_at",
+  desc: true,
+  next: "",
+  loaded: 0,
+  editing: null,
+};
+
+const $ = (id) => document.getElementById(id);
+
+// AuthError marks requests rejected for a missing or wrong token, after the login form is shown
+class AuthError extends Error {}
+
+// api sends an authenticated request and decodes the JSON response, failing with the error of the API
+async function api(method, path, body) {
+  const options = { method, headers: { Authorization: "Bearer " + state.token } };
+  if (body !== undefined) {
+    options.headers["Content-Type"] = "application/json";
+    options.body = JSON.stringify(body);
+  }
+  const resp = await fetch(path, options);
+  if (resp.status === 401) {
+    showLogin("The API token was rejected, enter the current one.");
+    throw new AuthError("unauthorized");
+  }
+  if (resp.status === 204) {
+    return null;
+  }
+  const data = await resp.json();
+  if (!resp.ok) {
+    throw new Error(data.error || resp.statusText);
+  }
+  return data;
+}
+
+// showMessage reports the outcome of an action above the table
+function showMessage(text, isError) {
+  const message = $("message");
+  message.textContent = text;
+  message.className = isError ? "error" : "";
+  message.hidden = !text;
+}
+
+// fail reports an error, unless it was a rejected token already handled by the login form
+function fail(err) {
+  if (!(err instanceof AuthError)) {
+    showMessage(err.message, true);
+  }
+}
+
+function showLogin(text) {
+  $("app").hidden = true;
+  $("login").hidden = false;
+  showMessage(text || "", Boolean(text));
+}
+
+// filterParams turns the filter form into query parameters of the API
+function filterParams() {
+  const params = new URLSearchParams();
+  for (const [name, value] of new FormData($("filters"))) {
+    if (value.trim() !== "") {
+      params.set(name, value.trim());
+    }
+  }
+  return params;
+}
+
+// loadApplications fills the table, appending the next page if more is set
+async function loadApplications(more) {
+  const params = filterParams();
+  params.set("sort", state.sort);
+  params.set("desc", String(state.desc));
+  params.set("limit", String(pageSize));
+  if (more && state.next) {
+    params.set("after", state.next);
+  }
+  const list = await api("GET", "/api/v1/applications?" + params);
+  const rows = $("rows");
+  if (!more) {
+    rows.replaceChildren();
+    state.loaded = 0;
+  }
+  for (const app of list.applications) {
+    rows.append(applicationRow(app));
+  }
+  state.loaded += list.applications.length;
+  state.next = list.next || "";
+  $("more-button").hidden = !state.next;
+  $("empty").hidden = list.total > 0;
+  $("summary").textContent = `${state.loaded} of ${list.total} application(s)`;
+  for (const th of document.querySelectorAll("th[data-sort]")) {
+    th.classList.toggle("sorted", th.dataset.sort === state.sort);
+    th.classList.toggle("desc", th.dataset.sort === state.sort && state.desc);
+  }
+}
+
+// applicationRow renders an application as a table row opening the editor on click
+function applicationRow(app) {
+  const tr = document.createElement("tr");
+  const cells = [String(app.id), app.company, app.position, app.status, null, app.created_at.slice(0, 10), app.updated_at.slice(0, 10)];
+  for (const text of cells) {
+    const td = document.createElement("td");
+    if (text === null) {
+      td.className = "tags";
+      for (const tag of app.tags || []) {
+        const span = document.createElement("span");
+        span.textContent = tag;
+        td.append(span);
+      }
+    } else {
+      td.textContent = text;
+    }
+    tr.append(td);
+  }
+  tr.addEventListener("click", () => openEditor(app));
+  return tr;
+}
+
+// loadStats draws the status funnel of the filtered applications
+async function loadStats() {
+  const report = await api("GET", "/api/v1/stats?" + filterParams());
+  const svg = $("funnel");
+  svg.replaceChildren();
+
+  const rowHeight = 26;
+  const labelWidth = 120;
+  const width = 800;
+  const barWidth = width - labelWidth - 160;
+  const max = Math.max(1, ...report.funnel.map((stage) => stage.reached));
+  svg.setAttribute("viewBox", `0 0 ${width} ${Math.max(1, report.funnel.length) * rowHeight}`);
+
+  report.funnel.forEach((stage, i) => {
+    const y = i * rowHeight;
+    const label = document.createElementNS(svgNS, "text");
+    label.setAttribute("x", "0");
+    label.setAttribute("y", String(y + 17));
+    label.textContent = stage.status;
+
+    const bar = document.createElementNS(svgNS, "rect");
+    const w = Math.max(2, (stage.reached / max) * barWidth);
+    bar.setAttribute("x", String(labelWidth));
+    bar.setAttribute("y", String(y + 4));
+    bar.setAttribute("width", String(w));
+    bar.setAttribute("height", String(rowHeight - 8));
+    bar.setAttribute("rx", "3");
+
+    const value = document.createElementNS(svgNS, "text");
+    value.setAttribute("x", String(labelWidth + w + 8));
+    value.setAttribute("y", String(y + 17));
+    value.textContent = `${stage.reached} (${stage.percent_of_total.toFixed(0)}%, ${stage.conversion.toFixed(0)}% from previous)`;
+
+    svg.append(label, bar, value);
+  });
+
+  const response = report.response;
+  $("response").textContent = report.total === 0
+    ? "No applications to analyze."
+    : `Response rate ${response.response_rate.toFixed(0)}% (${response.responded} of ${report.total}), ` +
+      `median of ${response.median_days_to_first_response.toFixed(1)} day(s) to the first response`;
+}
+
+// reload refreshes the table and the funnel
+function reload() {
+  return Promise.all([loadApplications(false), loadStats()]).catch(fail);
+}
+
+// statusChoices lists the statuses offered by the editor: all of them when forced or creating,
+// otherwise the current status and the ones the workflow allows moving to
+function statusChoices(app, force) {
+  const statuses = state.workflow.statuses;
+  if (!app) {
+    return statuses;
+  }
+  const choices = force ? statuses : (state.workflow.transitions[app.status] || []);
+  return [app.status, ...choices.filter((status) => status !== app.status)];
+}
+
+function fillStatuses() {
+  const select = $("editor-form").elements.status;
+  const current = select.value;
+  const choices = statusChoices(state.editing, $("editor-form").elements.force.checked);
+  select.replaceChildren(...choices.map((status) => new Option(status, status)));
+  if (choices.includes(current)) {
+    select.value = current;
+  }
+}
+
+function parseTags(text) {
+  return text.split(",").map((tag) => tag.trim()).filter((tag) => tag !== "");
+}
+
+// openEditor shows the form adding an application, or editing the given one
+function openEditor(app) {
+  state.editing = app;
+  const form = $("editor-form");
+  form.reset();
+  $("editor-title").textContent = app ? `Edit #${app.id}` : "New application";
+  $("delete-button").hidden = !app;
+  $("editor-error").hidden = true;
+  fillStatuses();
+  if (app) {
+    form.elements.company.value = app.company;
+    form.elements.position.value = app.position;
+    form.elements.status.value = app.status;
+    form.elements.tags.value = (app.tags || []).join(", ");
+  }
+  $("editor").showModal();
+}
+
+// saveEditor creates the application or sends the changed fields of the edited one
+async function saveEditor(event) {
+  event.preventDefault();
+  const form = $("editor-form").elements;
+  const app = state.editing;
+  const tags = parseTags(form.tags.value);
+  const force = form.force.checked;
+  try {
+    if (!app) {
+      const created = await api("POST", "/api/v1/applications", {
+        company: form.company.value, position: form.position.value, status: form.status.value, tags, force,
+      });
+      showMessage(`Job application added (ID: ${created.id})`);
+    } else {
+      const update = { force };
+      for (const name of ["company", "position", "status"]) {
+        if (form[name].value !== app[name]) {
+          update[name] = form[name].value;
+        }
+      }
+      const current = app.tags || [];
+      update.add_tags = tags.filter((tag) => !current.includes(tag.toLowerCase()));
+      update.remove_tags = current.filter((tag) => !tags.map((t) => t.toLowerCase()).includes(tag));
+      if (Object.keys(update).length > 1 || update.add_tags.length > 0 || update.remove_tags.length > 0) {
+        await api("PATCH", `/api/v1/applications/${app.id}`, update);
+        showMessage(`Job application #${app.id} updated`);
+      }
+    }
+    $("editor").close();
+    await reload();
+  } catch (err) {
+    if (!(err instanceof AuthError)) {
+      $("editor-error").textContent = err.message;
+      $("editor-error").hidden = false;
+    }
+  }
+}
+
+async function deleteEditing() {
+  const app = state.editing;
+  if (!app || !confirm(`Delete #${app.id} ${app.company} — ${app.position}?`)) {
+    return;
+  }
+  try {
+    await api("DELETE", `/api/v1/applications/${app.id}`);
+    $("editor").close();
+    showMessage(`Job application #${app.id} deleted`);
+    await reload();
+  } catch (err) {
+    $("editor").close();
+    fail(err);
+  }
+}
+
+// start loads the workflow and the data once a token is known
+async function start() {
+  if (!state.token) {
+    showLogin();
+    return;
+  }
+  try {
+    state.workflow = await api("GET", "/api/v1/workflow");
+  } catch (err) {
+    fail(err);
+    return;
+  }
+  const statusFilter = $("filters").elements.status;
+  statusFilter.replaceChildren(new Option("All statuses", ""), ...state.workflow.statuses.map((status) => new Option(status, status)));
+  $("login").hidden = true;
+  $("app").hidden = false;
+  showMessage("");
+  await reload();
+}
+
+function init() {
+  // `jobtracker serve` prints a link carrying the token in the fragment, which never reaches the server logs
+  const fragment = new URLSearchParams(location.hash.slice(1));
+  if (fragment.get("token")) {
+    state.token = fragment.get("token");
+    localStorage.setItem(tokenKey, state.token);
+    history.replaceState(null, "", location.pathname);
+  }
+
+  $("login-form").addEventListener("submit", (event) => {
+    event.preventDefault();
+    state.token = $("token").value.trim();
+    localStorage.setItem(tokenKey, state.token);
+    start();
+  });
+  $("logout-button").addEventListener("click", () => {
+    localStorage.removeItem(tokenKey);
+    state.token = "";
+    showLogin();
+  });
+
+  let timer;
+  $("filters").addEventListener("input", () => {
+    clearTimeout(timer);
+    timer = setTimeout(reload, 250);
+  });
+  $("filters").addEventListener("reset", () => setTimeout(reload));
+  for (const th of document.querySelectorAll("th[data-sort]")) {
+    th.addEventListener("click", () => {
+      state.desc = th.dataset.sort === state.sort ? !state.desc : false;
+      state.sort = th.dataset.sort;
+      loadApplications(false).catch(fail);
+    });
+  }
+  $("more-button").addEventListener("click", () => loadApplications(true).catch(fail));
+
+  $("new-button").addEventListener("click", () => openEditor(null));
+  $("editor-form").addEventListener("submit", saveEditor);
+  $("editor-form").elements.force.addEventListener("change", fillStatuses);
+  $("cancel-button").addEventListener("click", () => $("editor").close());
+  $("delete-button").addEventListener("click", deleteEditing);
+
+  start();
+}
+
+init();
@@ -0,0 +1,289 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package stats
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+// Report holds the pipeline analytics computed over job applications.
+type Report struct {
+	Total        int              `json:"total"`
+	StatusCounts []StatusCount    `json:"status_counts"`
+	Funnel       []FunnelStage    `json:"funnel"`
+	Response     ResponseStats    `json:"response"`
+	Weekly       []WeeklyCount    `json:"weekly"`
+	TimeInStatus []StatusDuration `json:"time_in_status"`
+}
+
+// StatusCount holds the number of applications currently in a status.
+type StatusCount struct {
+	Status  string  `json:"status"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// FunnelStage holds the number of applications that reached a pipeline stage.
+type FunnelStage struct {
+	Status         string  `json:"status"`
+	Reached        int     `json:"reached"`
+	PercentOfTotal float64 `json:"percent_of_total"`
+	Conversion     float64 `json:"conversion"`
+}
+
+// ResponseStats holds the response rate and the time to the first status change.
+type ResponseStats struct {
+	Responded                 int     `json:"responded"`
+	ResponseRate              float64 `json:"response_rate"`
+	MedianDaysToFirstResponse float64 `json:"median_days_to_first_response"`
+}
+
+// WeeklyCount holds the number of applications created in a week starting on Monday.
+type WeeklyCount struct {
+	WeekStart string `json:"week_start"`
+	Count     int    `json:"count"`
+}
+
+// StatusDuration holds the time applications spent in a status before moving on.
+type StatusDuration struct {
+	Status      string  `json:"status"`
+	Samples     int     `json:"samples"`
+	AverageDays float64 `json:"average_days"`
+	MedianDays  float64 `json:"median_days"`
+}
+
+// Compute builds a report from applications and their status history.
+// Statuses are ordered according to the workflow.
+func Compute(apps []db.JobApplication, history []db.StatusChange, workflow *db.Workflow) Report {
+	report := Report{Total: len(apps)}
+
+	byApp := make(map[int][]db.StatusChange)
+	for _, change := range history {
+		byApp[change.ApplicationID] = append(byApp[change.ApplicationID], change)
+	}
+	for id := range byApp {
+		changes := byApp[id]
+		sort.SliceStable(changes, func(i, j int) bool {
+			return changes[i].ChangedAt.Before(changes[j].ChangedAt)
+		})
+	}
+
+	report.StatusCounts = statusCounts(apps, workflow)
+	report.Funnel = funnel(apps, byApp, workflow)
+	report.Response = response(apps, byApp)
+	report.Weekly = weekly(apps)
+	report.TimeInStatus = timeInStatus(apps, byApp, workflow)
+	return report
+}
+
+// statusCounts counts applications per current status.
+func statusCounts(apps []db.JobApplication, workflow *db.Workflow) []StatusCount {
+	counts := make(map[string]int)
+	for _, app := range apps {
+		counts[canonical(workflow, app.Status)]++
+	}
+	var result []StatusCount
+	for _, status := range orderStatuses(counts, workflow) {
+		result = append(result, StatusCount{
+			Status:  status,
+			Count:   counts[status],
+			Percent: percent(counts[status], len(apps)),
+		})
+	}
+	return result
+}
+
+// funnel computes how many applications reached each non-final workflow stage.
+// An application reaching a later stage is counted as having passed the earlier ones.
+func funnel(apps []db.JobApplication, byApp map[int][]db.StatusChange, workflow *db.Workflow) []FunnelStage {
+	var stages []string
+	for _, status := range workflow.Statuses() {
+		if len(workflow.Next(status)) > 0 {
+			stages = append(stages, status)
+		}
+	}
+	index := make(map[string]int, len(stages))
+	for i, status := range stages {
+		index[status] = i
+	}
+
+	reached := make([]int, len(stages))
+	for _, app := range apps {
+		furthest := -1
+		visit := func(status string) {
+			if i, ok := index[canonical(workflow, status)]; ok && i > furthest {
+				furthest = i
+			}
+		}
+		visit(app.Status)
+		for _, change := range byApp[app.ID] {
+			visit(change.ToStatus)
+		}
+		for i := 0; i <= furthest; i++ {
+			reached[i]++
+		}
+	}
+
+	var result []FunnelStage
+	for i, status := range stages {
+		stage := FunnelStage{
+			Status:         status,
+			Reached:        reached[i],
+			PercentOfTotal: percent(reached[i], len(apps)),
+			Conversion:     100,
+		}
+		if i > 0 {
+			stage.Conversion = percent(reached[i], reached[i-1])
+		}
+		result = append(result, stage)
+	}
+	return result
+}
+
+// response computes the share of applications that moved past their initial
+// status and the median time it took.
+func response(apps []db.JobApplication, byApp map[int][]db.StatusChange) ResponseStats {
+	var days []float64
+	for _, app := range apps {
+		for _, change := range byApp[app.ID] {
+			if change.FromStatus == "" {
+				continue
+			}
+			days = append(days, change.ChangedAt.Sub(app.CreatedAt).Hours()/24)
+			break
+		}
+	}
+	return ResponseStats{
+		Responded:                 len(days),
+		ResponseRate:              percent(len(days), len(apps)),
+		MedianDaysToFirstResponse: median(days),
+	}
+}
+
+// weekly counts created applications per week, including empty weeks in between.
+func weekly(apps []db.JobApplication) []WeeklyCount {
+	if len(apps) == 0 {
+		return nil
+	}
+	counts := make(map[time.Time]int)
+	var first, last time.Time
+	for i, app := range apps {
+		week := weekStart(app.CreatedAt)
+		counts[week]++
+		if i == 0 || week.Before(first) {
+			first = week
+		}
+		if i == 0 || week.After(last) {
+			last = week
+		}
+	}
+	var result []WeeklyCount
+	for week := first; !week.After(last); week = week.AddDate(0, 0, 7) {
+		result = append(result, WeeklyCount{WeekStart: week.Format(time.DateOnly), Count: counts[week]})
+	}
+	return result
+}
+
+// timeInStatus computes how long applications stayed in each status before the next change.
+func timeInStatus(apps []db.JobApplication, byApp map[int][]db.StatusChange, workflow *db.Workflow) []StatusDuration {
+	samples := make(map[string][]float64)
+	for _, app := range apps {
+		changes := byApp[app.ID]
+		for i := 0; i+1 < len(changes); i++ {
+			status := canonical(workflow, changes[i].ToStatus)
+			days := changes[i+1].ChangedAt.Sub(changes[i].ChangedAt).Hours() / 24
+			samples[status] = append(samples[status], days)
+		}
+	}
+	counts := make(map[string]int, len(samples))
+	for status, values := range samples {
+		counts[status] = len(values)
+	}
+	var result []StatusDuration
+	for _, status := range orderStatuses(counts, workflow) {
+		values := samples[status]
+		result = append(result, StatusDuration{
+			Status:      status,
+			Samples:     len(values),
+			AverageDays: round(average(values)),
+			MedianDays:  median(values),
+		})
+	}
+	return result
+}
+
+// canonical returns the workflow spelling of a status, or the status itself if unknown.
+func canonical(workflow *db.Workflow, status string) string {
+	if name, err := workflow.NormalizeStatus(status); err == nil {
+		return name
+	}
+	return status
+}
+
+// orderStatuses orders statuses by workflow position, placing unknown ones alphabetically at the end.
+func orderStatuses(counts map[string]int, workflow *db.Workflow) []string {
+	var ordered []string
+	known := make(map[string]bool)
+	for _, status := range workflow.Statuses() {
+		known[status] = true
+		if counts[status] > 0 {
+			ordered = append(ordered, status)
+		}
+	}
+	var unknown []string
+	for status := range counts {
+		if !known[status] {
+			unknown = append(unknown, status)
+		}
+	}
+	sort.Strings(unknown)
+	return append(ordered, unknown...)
+}
+
+// weekStart returns midnight of the Monday starting the week of t.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+func percent(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return round(float64(part) * 100 / float64(total))
+}
+
+func average(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return round(sorted[mid])
+	}
+	return round((sorted[mid-1] + sorted[mid]) / 2)
+}
+
+// round rounds a value to one decimal place.
+func round(v float64) float64 {
+	return math.Round(v*10) / 10
+}
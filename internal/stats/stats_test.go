@@ -0,0 +1,155 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+// day returns midnight UTC of the given day in January 2026 (Jan 5 is a Monday)
+func day(d int) time.Time {
+	return time.Date(2026, 1, d, 0, 0, 0, 0, time.UTC)
+}
+
+func sampleData() ([]db.JobApplication, []db.StatusChange) {
+	apps := []db.JobApplication{
+		{ID: 1, Company: "Google", Status: "Offer", CreatedAt: day(5)},
+		{ID: 2, Company: "Apple", Status: "Rejected", CreatedAt: day(6)},
+		{ID: 3, Company: "Tesla", Status: "applied", CreatedAt: day(20)},
+		{ID: 4, Company: "Amazon", Status: "Interview", CreatedAt: day(21)},
+	}
+	history := []db.StatusChange{
+		{ApplicationID: 1, ToStatus: "Applied", ChangedAt: day(5)},
+		{ApplicationID: 1, FromStatus: "Applied", ToStatus: "Screening", ChangedAt: day(7)},
+		{ApplicationID: 1, FromStatus: "Screening", ToStatus: "Interview", ChangedAt: day(11)},
+		{ApplicationID: 1, FromStatus: "Interview", ToStatus: "Offer", ChangedAt: day(15)},
+		{ApplicationID: 2, ToStatus: "Applied", ChangedAt: day(6)},
+		{ApplicationID: 2, FromStatus: "Applied", ToStatus: "Rejected", ChangedAt: day(10)},
+		{ApplicationID: 3, ToStatus: "Applied", ChangedAt: day(20)},
+		// Application 4 skipped screening
+		{ApplicationID: 4, FromStatus: "Applied", ToStatus: "Interview", ChangedAt: day(27)},
+		{ApplicationID: 4, ToStatus: "Applied", ChangedAt: day(21)},
+	}
+	return apps, history
+}
+
+func TestComputeStatusCounts(t *testing.T) {
+	apps, history := sampleData()
+	report := Compute(apps, history, db.DefaultWorkflow())
+
+	if report.Total != 4 {
+		t.Errorf("Total = %d, want 4", report.Total)
+	}
+	want := []StatusCount{
+		{Status: "Applied", Count: 1, Percent: 25},
+		{Status: "Interview", Count: 1, Percent: 25},
+		{Status: "Offer", Count: 1, Percent: 25},
+		{Status: "Rejected", Count: 1, Percent: 25},
+	}
+	if !reflect.DeepEqual(report.StatusCounts, want) {
+		t.Errorf("StatusCounts = %+v, want %+v", report.StatusCounts, want)
+	}
+}
+
+func TestComputeFunnel(t *testing.T) {
+	apps, history := sampleData()
+	report := Compute(apps, history, db.DefaultWorkflow())
+
+	want := []FunnelStage{
+		{Status: "Applied", Reached: 4, PercentOfTotal: 100, Conversion: 100},
+		{Status: "Screening", Reached: 2, PercentOfTotal: 50, Conversion: 50},
+		{Status: "Interview", Reached: 2, PercentOfTotal: 50, Conversion: 100},
+		{Status: "Offer", Reached: 1, PercentOfTotal: 25, Conversion: 50},
+	}
+	if !reflect.DeepEqual(report.Funnel, want) {
+		t.Errorf("Funnel = %+v, want %+v", report.Funnel, want)
+	}
+}
+
+func TestComputeResponse(t *testing.T) {
+	apps, history := sampleData()
+	report := Compute(apps, history, db.DefaultWorkflow())
+
+	// First responses after 2, 4 and 6 days
+	want := ResponseStats{Responded: 3, ResponseRate: 75, MedianDaysToFirstResponse: 4}
+	if report.Response != want {
+		t.Errorf("Response = %+v, want %+v", report.Response, want)
+	}
+}
+
+func TestComputeWeekly(t *testing.T) {
+	apps, history := sampleData()
+	report := Compute(apps, history, db.DefaultWorkflow())
+
+	want := []WeeklyCount{
+		{WeekStart: "2026-01-05", Count: 2},
+		{WeekStart: "2026-01-12", Count: 0},
+		{WeekStart: "2026-01-19", Count: 2},
+	}
+	if !reflect.DeepEqual(report.Weekly, want) {
+		t.Errorf("Weekly = %+v, want %+v", report.Weekly, want)
+	}
+}
+
+func TestComputeTimeInStatus(t *testing.T) {
+	apps, history := sampleData()
+	report := Compute(apps, history, db.DefaultWorkflow())
+
+	want := []StatusDuration{
+		{Status: "Applied", Samples: 3, AverageDays: 4, MedianDays: 4},
+		{Status: "Screening", Samples: 1, AverageDays: 4, MedianDays: 4},
+		{Status: "Interview", Samples: 1, AverageDays: 4, MedianDays: 4},
+	}
+	if !reflect.DeepEqual(report.TimeInStatus, want) {
+		t.Errorf("TimeInStatus = %+v, want %+v", report.TimeInStatus, want)
+	}
+
+	// History of applications left out of apps (e.g. by a filter) is ignored
+	history = append(history,
+		db.StatusChange{ApplicationID: 5, ToStatus: "Applied", ChangedAt: day(1)},
+		db.StatusChange{ApplicationID: 5, FromStatus: "Applied", ToStatus: "Screening", ChangedAt: day(30)},
+	)
+	report = Compute(apps, history, db.DefaultWorkflow())
+	if !reflect.DeepEqual(report.TimeInStatus, want) {
+		t.Errorf("TimeInStatus with history of other applications = %+v, want %+v", report.TimeInStatus, want)
+	}
+}
+
+func TestComputeEmpty(t *testing.T) {
+	report := Compute(nil, nil, db.DefaultWorkflow())
+
+	if report.Total != 0 || len(report.StatusCounts) != 0 || len(report.Weekly) != 0 {
+		t.Errorf("Compute() on empty data = %+v, want empty report", report)
+	}
+	for _, stage := range report.Funnel {
+		if stage.Reached != 0 || stage.PercentOfTotal != 0 {
+			t.Errorf("Funnel stage %q = %+v, want zero counts", stage.Status, stage)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{"empty", nil, 0},
+		{"single", []float64{3}, 3},
+		{"odd", []float64{5, 1, 3}, 3},
+		{"even", []float64{4, 1, 2, 3}, 2.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := median(tt.values); got != tt.want {
+				t.Errorf("median(%v) = %v, want %v", tt.values, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+)
+
+// boardHelpLine lists the keys of the board
+const boardHelpLine = "←/→ column  ↑/↓ card  </> or shift+←/→ move card  r reload  q quit"
+
+// BoardModel is the state of the interactive board, moving cards between the columns of statuses
+type BoardModel struct {
+	ctx      context.Context
+	store    db.Store
+	filter   db.Filter
+	statuses []string
+
+	columns []display.BoardColumn
+	col     int
+	row     int
+	// selectedID keeps the selected card across reloads, following it to its new column
+	selectedID int
+	offset     int
+
+	width, height int
+
+	message string
+	err     error
+}
+
+// NewBoard creates the interactive board of the applications matching the filter, with a column per status
+func NewBoard(ctx context.Context, store db.Store, filter db.Filter, statuses []string) *BoardModel {
+	return &BoardModel{ctx: ctx, store: store, filter: filter, statuses: statuses, width: 80, height: 24}
+}
+
+// RunBoard shows the interactive board on the terminal until the user quits
+func RunBoard(ctx context.Context, store db.Store, filter db.Filter, statuses []string) error {
+	_, err := tea.NewProgram(NewBoard(ctx, store, filter, statuses), tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	return err
+}
+
+// Init loads the applications
+func (m *BoardModel) Init() tea.Cmd {
+	return m.load()
+}
+
+// load reads the applications, the recently updated ones first
+func (m *BoardModel) load() tea.Cmd {
+	return func() tea.Msg {
+		apps, err := m.store.Read(m.ctx, "updated_at", true, m.filter)
+		return appsMsg{apps: apps, err: err}
+	}
+}
+
+// selected returns the application of the selected card
+func (m *BoardModel) selected() (db.JobApplication, bool) {
+	if m.col >= len(m.columns) || m.row >= len(m.columns[m.col].Applications) {
+		return db.JobApplication{}, false
+	}
+	return m.columns[m.col].Applications[m.row], true
+}
+
+// selectCard moves the selection by columns and rows, keeping it within the board
+func (m *BoardModel) selectCard(dcol, drow int) {
+	if len(m.columns) == 0 {
+		return
+	}
+	m.col = max(0, min(m.col+dcol, len(m.columns)-1))
+	m.row = max(0, min(m.row+drow, len(m.columns[m.col].Applications)-1))
+	if app, ok := m.selected(); ok {
+		m.selectedID = app.ID
+	}
+}
+
+// Update handles key presses, window resizes and the results of background commands
+func (m *BoardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case appsMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.columns = display.GroupByStatus(msg.apps, m.statuses)
+		for i, col := range m.columns {
+			for j, app := range col.Applications {
+				if app.ID == m.selectedID {
+					m.col, m.row = i, j
+				}
+			}
+		}
+		m.selectCard(0, 0)
+		return m, nil
+	case doneMsg:
+		m.message, m.err = msg.message, msg.err
+		return m, m.load()
+	case tea.KeyMsg:
+		m.message, m.err = "", nil
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+		case "left", "h":
+			m.selectCard(-1, 0)
+		case "right", "l":
+			m.selectCard(1, 0)
+		case "up", "k":
+			m.selectCard(0, -1)
+		case "down", "j":
+			m.selectCard(0, 1)
+		case "shift+left", "<", "H":
+			return m, m.move(-1)
+		case "shift+right", ">", "L":
+			return m, m.move(1)
+		case "r":
+			return m, m.load()
+		}
+	}
+	return m, nil
+}
+
+// move moves the selected card to a neighboring column, changing the status of its application
+func (m *BoardModel) move(delta int) tea.Cmd {
+	app, ok := m.selected()
+	target := m.col + delta
+	if !ok || target < 0 || target >= len(m.columns) {
+		return nil
+	}
+	status := m.columns[target].Status
+	return func() tea.Msg {
+		_, err := m.store.Update(m.ctx, app.ID, map[string]string{"status": status}, false)
+		return doneMsg{message: fmt.Sprintf("Moved #%d %s to %s", app.ID, app.Company, status), err: err}
+	}
+}
+
+// View renders the board, scrolled to keep the selected card on the screen
+func (m *BoardModel) View() string {
+	lines, selectedLine := display.BoardLines(m.columns, m.width, m.selectedID, time.Now())
+	// The column titles stay on top, while the cards scroll
+	var header []string
+	if len(lines) >= 2 {
+		header, lines = lines[:2], lines[2:]
+		selectedLine -= 2
+	}
+	rows := max(1, m.height-len(header)-2)
+	if selectedLine >= 0 {
+		// Cards take three lines
+		m.offset = min(m.offset, selectedLine)
+		m.offset = max(m.offset, selectedLine+3-rows)
+	}
+	m.offset = max(0, min(m.offset, len(lines)-rows))
+
+	var b strings.Builder
+	title := fmt.Sprintf("JobTracker board · %d column(s)", len(m.columns))
+	b.WriteString(bold + fit(title, m.width) + reset + "\n")
+	for _, line := range header {
+		b.WriteString(line + "\n")
+	}
+	for i := m.offset; i < m.offset+rows; i++ {
+		if i < len(lines) {
+			b.WriteString(lines[i])
+		}
+		b.WriteString("\n")
+	}
+	line := m.message
+	if line == "" {
+		line = boardHelpLine
+	}
+	if m.err != nil {
+		line = "Error: " + m.err.Error()
+	}
+	b.WriteString(fit(line, m.width))
+	return b.String()
+}
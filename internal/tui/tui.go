@@ -0,0 +1,397 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package tui
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+)
+
+// mode is the interaction the screen is in
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeSearch
+	modeStatus
+	modeAdd
+	modeConfirmAdd
+	modeConfirmDelete
+)
+
+// column is a column of the application table, sorted by its database column
+type column struct {
+	title string
+	name  string
+	// width is the fixed width of the column, or 0 for columns sharing the remaining width
+	width int
+}
+
+var columns = []column{
+	{"ID", "id", 6},
+	{"Company", "company", 0},
+	{"Position", "position", 0},
+	{"Status", "status", 12},
+	{"Created", "created_at", 10},
+	{"Updated", "updated_at", 10},
+}
+
+// addFields are the fields of the add dialog
+var addFields = []string{"Company", "Position", "Status"}
+
+// Messages of the commands run in the background
+type (
+	appsMsg struct {
+		apps []db.JobApplication
+		err  error
+	}
+	detailsMsg struct {
+		details db.ApplicationDetails
+		err     error
+	}
+	doneMsg struct {
+		message string
+		err     error
+	}
+)
+
+// Model is the state of the full-screen application browser
+type Model struct {
+	ctx   context.Context
+	store db.Store
+
+	apps    []db.JobApplication
+	visible []db.JobApplication
+	cursor  int
+	offset  int
+	// selectedID keeps the selection across reloads
+	selectedID int
+
+	sortColumn int
+	descending bool
+	search     string
+
+	mode          mode
+	width, height int
+
+	showDetails bool
+	details     *db.ApplicationDetails
+
+	statusChoices []string
+	statusCursor  int
+
+	form      []string
+	formField int
+
+	message string
+	err     error
+}
+
+// New creates the application browser over a store, whose workflow drives status changes
+func New(ctx context.Context, store db.Store) *Model {
+	return &Model{ctx: ctx, store: store, width: 80, height: 24}
+}
+
+// Run shows the application browser on the terminal until the user quits
+func Run(ctx context.Context, store db.Store) error {
+	_, err := tea.NewProgram(New(ctx, store), tea.WithAltScreen(), tea.WithContext(ctx)).Run()
+	return err
+}
+
+// Init loads the applications
+func (m *Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// load reads the applications in the selected order
+func (m *Model) load() tea.Cmd {
+	sortBy, descending := columns[m.sortColumn].name, m.descending
+	return func() tea.Msg {
+		apps, err := m.store.Read(m.ctx, sortBy, descending, db.Filter{})
+		return appsMsg{apps: apps, err: err}
+	}
+}
+
+// loadDetails reads the notes and status history of the selected application
+func (m *Model) loadDetails() tea.Cmd {
+	app, ok := m.selected()
+	if !m.showDetails || !ok {
+		m.details = nil
+		return nil
+	}
+	return func() tea.Msg {
+		notes, err := m.store.ReadNotes(m.ctx, app.ID)
+		if err != nil {
+			return detailsMsg{err: err}
+		}
+		history, err := m.store.History(m.ctx, app.ID)
+		app.Notes = notes
+		return detailsMsg{details: db.ApplicationDetails{JobApplication: app, History: history}, err: err}
+	}
+}
+
+// selected returns the application under the cursor
+func (m *Model) selected() (db.JobApplication, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.visible) {
+		return db.JobApplication{}, false
+	}
+	return m.visible[m.cursor], true
+}
+
+// applySearch narrows the applications down to the ones matching the search text,
+// keeping the selected application under the cursor if it still matches
+func (m *Model) applySearch() {
+	query := strings.ToLower(m.search)
+	m.visible = m.visible[:0]
+	for _, app := range m.apps {
+		text := strings.ToLower(strings.Join(append([]string{app.Company, app.Position, app.Status}, app.Tags...), " "))
+		if strings.Contains(text, query) {
+			m.visible = append(m.visible, app)
+		}
+	}
+	m.cursor = slices.IndexFunc(m.visible, func(app db.JobApplication) bool { return app.ID == m.selectedID })
+	m.moveCursor(0)
+}
+
+// moveCursor moves the cursor by delta rows, keeping it on the screen
+func (m *Model) moveCursor(delta int) {
+	m.cursor = max(0, min(m.cursor+delta, len(m.visible)-1))
+	if app, ok := m.selected(); ok {
+		m.selectedID = app.ID
+	}
+	rows := m.tableRows()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+rows {
+		m.offset = m.cursor - rows + 1
+	}
+	m.offset = max(0, min(m.offset, len(m.visible)-rows))
+}
+
+// Update handles key presses, window resizes and the results of background commands
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.moveCursor(0)
+		return m, nil
+	case appsMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.apps = msg.apps
+		m.applySearch()
+		return m, m.loadDetails()
+	case detailsMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if msg.details.ID == m.selectedID {
+			m.details = &msg.details
+		}
+		return m, nil
+	case doneMsg:
+		m.message, m.err = msg.message, msg.err
+		return m, m.load()
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		switch m.mode {
+		case modeSearch:
+			return m.updateSearch(msg)
+		case modeStatus:
+			return m.updateStatus(msg)
+		case modeAdd:
+			return m.updateAdd(msg)
+		case modeConfirmAdd, modeConfirmDelete:
+			return m.updateConfirm(msg)
+		}
+		return m.updateBrowse(msg)
+	}
+	return m, nil
+}
+
+// updateBrowse handles the keys of the application table
+func (m *Model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.message, m.err = "", nil
+	key := msg.String()
+	previous := m.selectedID
+	switch key {
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.moveCursor(-1)
+	case "down", "j":
+		m.moveCursor(1)
+	case "pgup":
+		m.moveCursor(-m.tableRows())
+	case "pgdown", " ":
+		m.moveCursor(m.tableRows())
+	case "home", "g":
+		m.moveCursor(-len(m.visible))
+	case "end", "G":
+		m.moveCursor(len(m.visible))
+	case "/":
+		m.mode = modeSearch
+	case "esc":
+		m.search = ""
+		m.applySearch()
+	case "1", "2", "3", "4", "5", "6":
+		// Selecting the sorted column again reverses the order
+		col := int(key[0] - '1')
+		m.descending = col == m.sortColumn && !m.descending
+		m.sortColumn = col
+		return m, m.load()
+	case "enter", "tab":
+		m.showDetails = !m.showDetails
+		m.moveCursor(0)
+		return m, m.loadDetails()
+	case "s":
+		app, ok := m.selected()
+		if !ok {
+			return m, nil
+		}
+		m.statusChoices = m.store.Workflow().Next(app.Status)
+		if len(m.statusChoices) == 0 {
+			m.message = fmt.Sprintf("%q is a final status, it cannot be changed here", app.Status)
+			return m, nil
+		}
+		m.mode, m.statusCursor = modeStatus, 0
+	case "a":
+		initial := ""
+		if statuses := m.store.Workflow().Statuses(); len(statuses) > 0 {
+			initial = statuses[0]
+		}
+		m.mode, m.form, m.formField = modeAdd, []string{"", "", initial}, 0
+	case "d":
+		if _, ok := m.selected(); ok {
+			m.mode = modeConfirmDelete
+		}
+	case "r":
+		return m, m.load()
+	}
+	if m.selectedID != previous {
+		m.details = nil
+		return m, m.loadDetails()
+	}
+	return m, nil
+}
+
+// updateSearch edits the search text, narrowing the table down while typing
+func (m *Model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.mode = modeBrowse
+	case tea.KeyEsc:
+		m.mode, m.search = modeBrowse, ""
+	case tea.KeyBackspace:
+		if runes := []rune(m.search); len(runes) > 0 {
+			m.search = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.search += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	previous := m.selectedID
+	m.applySearch()
+	if m.selectedID != previous {
+		m.details = nil
+		return m, m.loadDetails()
+	}
+	return m, nil
+}
+
+// updateStatus picks the new status of the selected application
+func (m *Model) updateStatus(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mode = modeBrowse
+	case "up", "k", "left", "h":
+		m.statusCursor = max(0, m.statusCursor-1)
+	case "down", "j", "right", "l":
+		m.statusCursor = min(len(m.statusChoices)-1, m.statusCursor+1)
+	case "enter":
+		m.mode = modeBrowse
+		app, _ := m.selected()
+		status := m.statusChoices[m.statusCursor]
+		return m, func() tea.Msg {
+			_, err := m.store.Update(m.ctx, app.ID, map[string]string{"status": status}, false)
+			return doneMsg{message: fmt.Sprintf("Status of #%d changed to %s", app.ID, status), err: err}
+		}
+	}
+	return m, nil
+}
+
+// updateAdd edits the fields of the add dialog
+func (m *Model) updateAdd(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.mode = modeBrowse
+	case tea.KeyTab, tea.KeyDown:
+		m.formField = (m.formField + 1) % len(addFields)
+	case tea.KeyShiftTab, tea.KeyUp:
+		m.formField = (m.formField + len(addFields) - 1) % len(addFields)
+	case tea.KeyEnter:
+		if m.formField < len(addFields)-1 {
+			m.formField++
+			return m, nil
+		}
+		for i, field := range addFields {
+			if strings.TrimSpace(m.form[i]) == "" {
+				m.formField, m.err = i, fmt.Errorf("%s cannot be empty", strings.ToLower(field))
+				return m, nil
+			}
+		}
+		m.mode, m.err = modeConfirmAdd, nil
+	case tea.KeyBackspace:
+		if runes := []rune(m.form[m.formField]); len(runes) > 0 {
+			m.form[m.formField] = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.form[m.formField] += string(msg.Runes)
+	}
+	return m, nil
+}
+
+// updateConfirm asks for confirmation of adding or deleting an application
+func (m *Model) updateConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+	case "n", "N", "esc":
+		if m.mode == modeConfirmAdd {
+			// Back to the dialog to fix the fields
+			m.mode = modeAdd
+		} else {
+			m.mode = modeBrowse
+		}
+		return m, nil
+	default:
+		return m, nil
+	}
+
+	confirmed := m.mode
+	m.mode = modeBrowse
+	if confirmed == modeConfirmAdd {
+		company, position, status := m.form[0], m.form[1], m.form[2]
+		return m, func() tea.Msg {
+			id, err := m.store.Add(m.ctx, company, position, status, nil, false)
+			return doneMsg{message: fmt.Sprintf("Job application added (ID: %d)", id), err: err}
+		}
+	}
+	app, _ := m.selected()
+	return m, func() tea.Msg {
+		_, err := m.store.Delete(m.ctx, app.ID)
+		return doneMsg{message: fmt.Sprintf("Job application #%d deleted", app.ID), err: err}
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package tui
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/db/dbtest"
+)
+
+// newModel opens a browser over a migrated SQLite database holding a few applications
+func newModel(t *testing.T) (*Model, db.Store) {
+	t.Helper()
+	store := dbtest.NewSQLiteStore(t, dbtest.Applications()...)
+	m := New(context.Background(), store)
+	run(t, m, m.Init())
+	return m, store
+}
+
+// run executes a command and feeds its message back into the model, like the program loop does
+func run(t *testing.T, m *Model, cmd tea.Cmd) {
+	t.Helper()
+	for cmd != nil {
+		msg := cmd()
+		if _, ok := msg.(tea.QuitMsg); ok {
+			return
+		}
+		_, cmd = m.Update(msg)
+	}
+	if m.err != nil {
+		t.Fatalf("unexpected error: %v", m.err)
+	}
+}
+
+// press sends key presses to the model, typing runes for keys without a name
+func press(t *testing.T, m *Model, keys ...string) {
+	t.Helper()
+	named := map[string]tea.KeyType{
+		"enter": tea.KeyEnter, "esc": tea.KeyEsc, "tab": tea.KeyTab,
+		"backspace": tea.KeyBackspace, "up": tea.KeyUp, "down": tea.KeyDown, "right": tea.KeyRight,
+	}
+	for _, key := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		if keyType, ok := named[key]; ok {
+			msg = tea.KeyMsg{Type: keyType}
+		}
+		_, cmd := m.Update(msg)
+		run(t, m, cmd)
+	}
+}
+
+// companies lists the companies of the visible applications in order
+func companies(m *Model) string {
+	names := make([]string, len(m.visible))
+	for i, app := range m.visible {
+		names[i] = app.Company
+	}
+	return strings.Join(names, ",")
+}
+
+func TestSearchAndSort(t *testing.T) {
+	m, _ := newModel(t)
+	if got := companies(m); got != "Google,Apple,Stripe" {
+		t.Fatalf("initial applications = %s, want in ID order", got)
+	}
+
+	press(t, m, "/", "e", "n", "g")
+	if got := companies(m); got != "Google,Stripe" {
+		t.Errorf("applications while searching = %s, want Google,Stripe", got)
+	}
+	press(t, m, "enter")
+	if m.mode != modeBrowse || m.search != "eng" {
+		t.Errorf("enter should keep the search, got mode %d and search %q", m.mode, m.search)
+	}
+	press(t, m, "esc")
+	if got := companies(m); got != "Google,Apple,Stripe" {
+		t.Errorf("applications after clearing the search = %s, want all", got)
+	}
+
+	press(t, m, "2")
+	if got := companies(m); got != "Apple,Google,Stripe" {
+		t.Errorf("applications sorted by company = %s", got)
+	}
+	press(t, m, "2")
+	if got := companies(m); got != "Stripe,Google,Apple" || !m.descending {
+		t.Errorf("applications sorted by company again = %s, want reversed", got)
+	}
+}
+
+func TestStatusChange(t *testing.T) {
+	m, store := newModel(t)
+	press(t, m, "down", "s")
+	if m.mode != modeStatus || m.statusChoices[0] != "Screening" {
+		t.Fatalf("status picker = %v in mode %d, want the workflow transitions", m.statusChoices, m.mode)
+	}
+	press(t, m, "right", "enter")
+	app, err := store.Get(context.Background(), 2)
+	if err != nil || app.Status != m.statusChoices[1] {
+		t.Errorf("status after change = %q, %v, want %q", app.Status, err, m.statusChoices[1])
+	}
+	if selected, _ := m.selected(); selected.ID != 2 || selected.Status != app.Status {
+		t.Errorf("selection after reload = %+v, want the changed application", selected)
+	}
+}
+
+func TestAddAndDelete(t *testing.T) {
+	m, store := newModel(t)
+	ctx := context.Background()
+
+	press(t, m, "a", "M", "e", "t", "a", "tab", "S", "R", "E", "enter", "enter")
+	if m.mode != modeConfirmAdd {
+		t.Fatalf("mode after filling the dialog = %d, want the confirmation", m.mode)
+	}
+	press(t, m, "y")
+	if n, _ := store.Count(ctx, db.Filter{}); n != 4 || !strings.HasSuffix(companies(m), ",Meta") {
+		t.Errorf("applications after adding = %d (%s), want Meta added", n, companies(m))
+	}
+
+	press(t, m, "d", "n")
+	if n, _ := store.Count(ctx, db.Filter{}); n != 4 || m.mode != modeBrowse {
+		t.Errorf("declined delete removed an application or left mode %d", m.mode)
+	}
+	press(t, m, "d", "y")
+	if _, err := store.Get(ctx, 1); err == nil {
+		t.Error("confirmed delete kept the selected application")
+	}
+	if got := companies(m); got != "Apple,Stripe,Meta" {
+		t.Errorf("applications after deleting = %s", got)
+	}
+}
+
+func TestDetailsAndView(t *testing.T) {
+	m, store := newModel(t)
+	if _, err := store.AddNote(context.Background(), 1, "Recruiter call"); err != nil {
+		t.Fatal(err)
+	}
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	press(t, m, "enter")
+	if m.details == nil || m.details.ID != 1 || len(m.details.Notes) != 1 {
+		t.Fatalf("details = %+v, want the notes of the selected application", m.details)
+	}
+	view := m.View()
+	for _, want := range []string{"3 of 3 application(s)", "Google", "#1 Google — Engineer", "Recruiter call"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View() does not contain %q:\n%s", want, view)
+		}
+	}
+	if lines := strings.Count(view, "\n") + 1; lines != 30 {
+		t.Errorf("View() has %d lines, want the height of the window", lines)
+	}
+}
+
+func TestBoardMoveCard(t *testing.T) {
+	_, store := newModel(t)
+	ctx := context.Background()
+	b := NewBoard(ctx, store, db.Filter{}, store.Workflow().Statuses())
+	step := func(cmd tea.Cmd) {
+		t.Helper()
+		for cmd != nil {
+			_, cmd = b.Update(cmd())
+		}
+		if b.err != nil {
+			t.Fatalf("unexpected error: %v", b.err)
+		}
+	}
+	step(b.Init())
+	if len(b.columns[0].Applications) != 3 {
+		t.Fatalf("first column = %+v, want the three applied applications", b.columns[0])
+	}
+
+	app, _ := b.selected()
+	_, cmd := b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")})
+	step(cmd)
+	moved, err := store.Get(ctx, app.ID)
+	if err != nil || moved.Status != b.columns[1].Status {
+		t.Errorf("status after moving = %q, %v, want %q", moved.Status, err, b.columns[1].Status)
+	}
+	if selected, _ := b.selected(); b.col != 1 || selected.ID != app.ID {
+		t.Errorf("selection after moving = column %d, #%d, want the moved card", b.col, selected.ID)
+	}
+
+	// Cards cannot be moved past the last column
+	b.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	b.selectCard(len(b.columns), 0)
+	if _, cmd := b.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(">")}); cmd != nil {
+		t.Error("moving a card out of the last column should do nothing")
+	}
+	if view := b.View(); !strings.Contains(view, "APPLIED (2)") || !strings.Contains(view, "SCREENING (1)") {
+		t.Errorf("View() does not show the column counts:\n%s", view)
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright © 2026 Sergey Polivin <s.polivin@gmail.com>
+*/
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/spolivin/jobtracker/v2/internal/db"
+	"github.com/spolivin/jobtracker/v2/internal/display"
+)
+
+// ANSI sequences highlighting the selection
+const (
+	reverse = "\x1b[7m"
+	bold    = "\x1b[1m"
+	reset   = "\x1b[0m"
+)
+
+// detailsHeight is the number of lines taken by the detail pane
+const detailsHeight = 10
+
+// helpLine lists the keys of the application table
+const helpLine = "↑/↓ move  / search  1-6 sort  enter details  s status  a add  d delete  r reload  q quit"
+
+// tableRows returns the number of application rows fitting on the screen
+func (m *Model) tableRows() int {
+	// Title, table header and status line
+	rows := m.height - 3
+	if m.showDetails {
+		rows -= detailsHeight
+	}
+	return max(1, rows)
+}
+
+// View renders the screen
+func (m *Model) View() string {
+	var b strings.Builder
+	m.writeTitle(&b)
+	m.writeTable(&b)
+	if m.showDetails {
+		m.writeDetails(&b)
+	}
+	b.WriteString(m.statusLine())
+	return b.String()
+}
+
+// writeTitle writes the line with the number of applications and the sort order
+func (m *Model) writeTitle(b *strings.Builder) {
+	order := "ascending"
+	if m.descending {
+		order = "descending"
+	}
+	title := fmt.Sprintf("JobTracker · %d of %d application(s) · sorted by %s (%s)",
+		len(m.visible), len(m.apps), strings.ToLower(columns[m.sortColumn].title), order)
+	if m.search != "" {
+		title += fmt.Sprintf(" · search %q", m.search)
+	}
+	b.WriteString(bold + fit(title, m.width) + reset + "\n")
+}
+
+// columnWidths distributes the width of the screen among the columns
+func (m *Model) columnWidths() []int {
+	widths := make([]int, len(columns))
+	fixed, flexible := 0, 0
+	for i, col := range columns {
+		widths[i] = col.width
+		fixed += col.width + 1
+		if col.width == 0 {
+			flexible++
+		}
+	}
+	for i, col := range columns {
+		if col.width == 0 {
+			widths[i] = max(8, (m.width-fixed)/flexible-1)
+		}
+	}
+	return widths
+}
+
+// writeTable writes the visible rows of the application table
+func (m *Model) writeTable(b *strings.Builder) {
+	widths := m.columnWidths()
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		title := strconv.Itoa(i+1) + ":" + col.title
+		if i == m.sortColumn && m.descending {
+			title += " ↓"
+		} else if i == m.sortColumn {
+			title += " ↑"
+		}
+		header[i] = fit(title, widths[i])
+	}
+	b.WriteString(bold + fit(strings.Join(header, " "), m.width) + reset + "\n")
+
+	rows := m.tableRows()
+	for i := m.offset; i < m.offset+rows; i++ {
+		if i >= len(m.visible) {
+			b.WriteString("\n")
+			continue
+		}
+		app := m.visible[i]
+		cells := []string{
+			strconv.Itoa(app.ID),
+			app.Company,
+			app.Position,
+			app.Status,
+			app.CreatedAt.Local().Format(time.DateOnly),
+			app.UpdatedAt.Local().Format(time.DateOnly),
+		}
+		for j := range cells {
+			cells[j] = fit(cells[j], widths[j])
+		}
+		line := fit(strings.Join(cells, " "), m.width)
+		if i == m.cursor {
+			line = reverse + line + reset
+		}
+		b.WriteString(line + "\n")
+	}
+}
+
+// writeDetails writes the detail pane of the selected application
+func (m *Model) writeDetails(b *strings.Builder) {
+	lines := []string{bold + fit("─── Details ", m.width) + reset}
+	switch app, ok := m.selected(); {
+	case !ok:
+		lines = append(lines, "No application selected")
+	case m.details == nil || m.details.ID != app.ID:
+		lines = append(lines, "Loading…")
+	default:
+		lines = append(lines, detailLines(*m.details)...)
+	}
+	for i := range detailsHeight {
+		if i < len(lines) {
+			b.WriteString(fit(lines[i], m.width))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// detailLines describes an application with its status history and latest notes
+func detailLines(d db.ApplicationDetails) []string {
+	tags := strings.Join(d.Tags, ", ")
+	if tags == "" {
+		tags = "-"
+	}
+	lines := []string{
+		fmt.Sprintf("#%d %s — %s", d.ID, d.Company, d.Position),
+		fmt.Sprintf("Status: %s   Tags: %s", d.Status, tags),
+		fmt.Sprintf("Created: %s   Updated: %s (%s)",
+			d.CreatedAt.Local().Format(time.DateTime), d.UpdatedAt.Local().Format(time.DateTime), display.FormatAgo(time.Since(d.UpdatedAt))),
+	}
+	history := make([]string, len(d.History))
+	for i, change := range d.History {
+		history[i] = change.ToStatus + " " + change.ChangedAt.Local().Format(time.DateOnly)
+	}
+	lines = append(lines, "History: "+strings.Join(history, " → "))
+	lines = append(lines, fmt.Sprintf("Notes (%d):", len(d.Notes)))
+	// The latest notes first, on a line each
+	for i := len(d.Notes) - 1; i >= 0; i-- {
+		content, _, _ := strings.Cut(d.Notes[i].Content, "\n")
+		lines = append(lines, fmt.Sprintf("  #%d %s", d.Notes[i].ID, content))
+	}
+	return lines
+}
+
+// statusLine renders the bottom line: the prompt of a dialog, a message or the help
+func (m *Model) statusLine() string {
+	var line string
+	switch m.mode {
+	case modeSearch:
+		line = "Search: " + m.search + "█"
+	case modeStatus:
+		choices := make([]string, len(m.statusChoices))
+		for i, status := range m.statusChoices {
+			if i == m.statusCursor {
+				status = reverse + status + reset
+			}
+			choices[i] = status
+		}
+		app, _ := m.selected()
+		// Highlighted choices are not truncated, as escape sequences would be cut
+		return fmt.Sprintf("Status of #%d %s → %s  (←/→ choose, enter apply, esc cancel)", app.ID, app.Status, strings.Join(choices, " "))
+	case modeAdd:
+		fields := make([]string, len(addFields))
+		for i, field := range addFields {
+			value := m.form[i]
+			if i == m.formField {
+				value += "█"
+			}
+			fields[i] = field + ": " + value
+		}
+		line = "Add · " + strings.Join(fields, " | ") + "  (tab next field, enter confirm, esc cancel)"
+	case modeConfirmAdd:
+		line = fmt.Sprintf("Add %s — %s (%s)? [y/n]", m.form[0], m.form[1], m.form[2])
+	case modeConfirmDelete:
+		app, _ := m.selected()
+		line = fmt.Sprintf("Delete #%d %s — %s along with its notes and history? [y/n]", app.ID, app.Company, app.Position)
+	default:
+		line = m.message
+		if line == "" {
+			line = helpLine
+		}
+	}
+	if m.err != nil {
+		line = "Error: " + m.err.Error()
+		if m.mode == modeAdd {
+			line += " · " + strings.Join(m.form, " | ")
+		}
+	}
+	return fit(line, m.width)
+}
+
+// fit truncates or pads a string to a display width
+func fit(s string, width int) string {
+	return runewidth.FillRight(runewidth.Truncate(s, width, "…"), width)
+}